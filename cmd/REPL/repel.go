@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/houcine7/JIPL/internal/debug"
+	"github.com/houcine7/JIPL/internal/diagnostics"
 	"github.com/houcine7/JIPL/internal/lexer"
 	"github.com/houcine7/JIPL/internal/parser"
 	"github.com/houcine7/JIPL/internal/runtime"
@@ -32,6 +33,8 @@ const (
 
 func Start(in io.Reader, out io.Writer) {
 	scanner := bufio.NewScanner(in)
+	renderer := diagnostics.NewRenderer()
+	inputs := 0
 
 	fmt.Println(`  _ _____ _____  _        
       | |_   _|  __ \| |       
@@ -68,40 +71,13 @@ func Start(in io.Reader, out io.Writer) {
 			break
 		}
 
-		replLexer := lexer.InitLexer(line)
-		repParser := parser.InitParser(replLexer)
+		// every input is a source of its own, the errors of the functions
+		// defined by the previous inputs point to the right lines
+		inputs++
+		inputName := fmt.Sprintf("<input %d>", inputs)
+		renderer.AddSource(inputName, line)
 
-		pr := repParser.Parse()
-		errs := repParser.Errors()
-
-		if len(errs) != 0 {
-			io.WriteString(out, fmt.Sprintf("%d errors ❌ occurred while parsing your input \n", len(errs)))
-			for idx, e := range errs {
-				io.WriteString(out, fmt.Sprintf("error number:%d with message: %s \n", idx, e.Message))
-			}
-			continue
-		}
-
-		afterParsing := time.Since(start)
-
-		if isDebugging {
-			fmt.Printf("parsing step for %s took %s \n", line, afterParsing)
-		}
-
-		evaluated, err := runtime.Eval(pr, ctx)
-		if err != debug.NOERROR {
-			io.WriteString(out, fmt.Sprintf("error while evaluating your input: %s \n", err.Error()))
-			continue
-		}
-
-		if isDebugging {
-			fmt.Printf("expression evaluations  step for %s took %s \n", line, afterParsing)
-		}
-
-		if evaluated != nil {
-			io.WriteString(out, evaluated.ToString())
-			io.WriteString(out, "\n")
-		}
+		runInput(inputName, line, start, renderer, out)
 
 		// take memory snapshot
 		if enableMemProfiling {
@@ -115,3 +91,52 @@ func Start(in io.Reader, out io.Writer) {
 		}
 	}
 }
+
+/*
+* Parses and evaluates an input of the repl. it's the recover barrier of the session:
+* a go panic while handling the input is reported as an error and the repl continues
+ */
+func runInput(inputName, line string, start time.Time, renderer *diagnostics.Renderer, out io.Writer) {
+	defer func() {
+		if r := recover(); r != nil {
+			err := debug.NewError(fmt.Sprintf("internal error: %v", r))
+			io.WriteString(out, renderer.Render(diagnostics.FromRuntimeError(err)))
+		}
+	}()
+
+	replLexer := lexer.InitFileLexer(inputName, line)
+	repParser := parser.InitParser(replLexer)
+
+	pr := repParser.Parse()
+	errs := repParser.Errors()
+
+	if len(errs) != 0 {
+		io.WriteString(out, fmt.Sprintf("%d errors ❌ occurred while parsing your input \n", len(errs)))
+		for _, e := range errs {
+			io.WriteString(out, renderer.Render(diagnostics.FromParserError(e)))
+		}
+		return
+	}
+
+	afterParsing := time.Since(start)
+
+	if isDebugging {
+		fmt.Printf("parsing step for %s took %s \n", line, afterParsing)
+	}
+
+	evaluated, err := runtime.Eval(pr, ctx)
+	if err != debug.NOERROR {
+		io.WriteString(out, renderer.Render(diagnostics.FromRuntimeError(err)))
+		return
+	}
+
+	if isDebugging {
+		fmt.Printf("expression evaluations  step for %s took %s \n", line, afterParsing)
+	}
+
+	// the statements without a value (def, loops, out(...)) don't echo anything
+	if evaluated != nil && evaluated != types.UNDEFIEND {
+		io.WriteString(out, evaluated.ToString())
+		io.WriteString(out, "\n")
+	}
+}
@@ -2,13 +2,25 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/user"
 
 	repl "github.com/houcine7/JIPL/cmd/REPL"
+	"github.com/houcine7/JIPL/internal/debug"
+	"github.com/houcine7/JIPL/internal/diagnostics"
+	"github.com/houcine7/JIPL/internal/lexer"
+	"github.com/houcine7/JIPL/internal/parser"
+	"github.com/houcine7/JIPL/internal/runtime"
+	"github.com/houcine7/JIPL/internal/types"
 )
 
 func main() {
+	// jipl <file> runs the file, without arguments the repl is started
+	if len(os.Args) > 1 {
+		os.Exit(runFile(os.Args[1], os.Stderr))
+	}
+
 	currUser, err := user.Current()
 
 	if err != nil {
@@ -20,3 +32,45 @@ func main() {
 
 	repl.Start(os.Stdin, os.Stdout)
 }
+
+/*
+* Runs a JIPL file and writes its errors as diagnostics,
+* returns the exit code of the process
+ */
+func runFile(path string, errOut io.Writer) (code int) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(errOut, "couldn't read the file %s: %s\n", path, err)
+		return 1
+	}
+	source := string(content)
+
+	renderer := diagnostics.NewRenderer()
+	renderer.AddSource(path, source)
+
+	// a go panic of the interpreter is reported like the other errors
+	defer func() {
+		if r := recover(); r != nil {
+			err := debug.NewError(fmt.Sprintf("internal error: %v", r))
+			io.WriteString(errOut, renderer.Render(diagnostics.FromRuntimeError(err)))
+			code = 1
+		}
+	}()
+
+	p := parser.InitParser(lexer.InitFileLexer(path, source))
+	program := p.Parse()
+
+	if errs := p.Errors(); len(errs) != 0 {
+		for _, e := range errs {
+			io.WriteString(errOut, renderer.Render(diagnostics.FromParserError(e)))
+		}
+		fmt.Fprintf(errOut, "%d errors occurred while parsing %s\n", len(errs), path)
+		return 1
+	}
+
+	if _, evalErr := runtime.Eval(program, types.NewContext()); evalErr != debug.NOERROR {
+		io.WriteString(errOut, renderer.Render(diagnostics.FromRuntimeError(evalErr)))
+		return 1
+	}
+	return 0
+}
@@ -2,6 +2,7 @@ package ast
 
 import (
 	"bytes"
+	"math/big"
 
 	"github.com/houcine7/JIPL/internal/token"
 )
@@ -15,9 +16,10 @@ type Program struct {
 }
 
 type DefStatement struct {
-	Token token.Token // toke.DEF token
-	Name  *Identifier
-	Value Expression
+	Token   token.Token // toke.DEF token
+	Name    *Identifier
+	Pattern *ArrayLiteral // def [a, b, ...rest] = arr; the Name is nil
+	Value   Expression
 }
 
 type Identifier struct { //
@@ -30,16 +32,45 @@ type ReturnStatement struct {
 	ReturnValue Expression
 }
 
+type BreakStatement struct {
+	Token token.Token // the break token
+}
+
+type ContinueStatement struct {
+	Token token.Token // the continue token
+}
+
 type BooleanExp struct {
 	Token token.Token
 	Value bool // the boolean value corresponds to bool
 }
 
+// the null literal, it evaluates to undefined
+type NullLiteral struct {
+	Token token.Token
+}
+
 type FunctionExp struct {
-	Token      token.Token   // the function token used to represent functions
-	Name       *Identifier   // the name of the functoin
-	Parameters []*Identifier // function parmas
-	FnBody     *BlockStm     // function body
+	Token      token.Token     // the function token used to represent functions
+	Name       *Identifier     // the name of the functoin, nil for anonymous functions
+	Parameters []*Identifier   // function parmas, nil for the destructured ones
+	Defaults   []Expression    // the default values of the params, nil for the required ones
+	Patterns   []*ArrayLiteral // the destructured params [a, b], nil for the named ones
+	Rest       *Identifier     // the rest param collecting the extra args ...rest
+	FnBody     *BlockStm       // function body
+}
+
+// a named argument of a function call connect(host = "x")
+type NamedArgument struct {
+	Token token.Token // the = token
+	Name  *Identifier
+	Value Expression
+}
+
+// the spread of an array ...arr in function calls and array literals
+type SpreadExpression struct {
+	Token token.Token // the ... token
+	Value Expression
 }
 
 type FunctionCall struct {
@@ -61,6 +92,12 @@ type StringLiteral struct {
 type IntegerLiteral struct {
 	Token token.Token
 	Value int
+	Big   *big.Int // set when the literal doesn't fit in an int
+}
+
+type FloatLiteral struct {
+	Token token.Token
+	Value float64
 }
 
 type PrefixExpression struct {
@@ -78,12 +115,31 @@ type InfixExpression struct {
 
 type ForLoopExpression struct {
 	Token         token.Token // the 'for' token idencate for loop starting point
-	InitStm       Statement   // the initialization stm
-	Condition     Expression  // loop condition
-	PostIteration Expression  // the post iteration expression
+	InitStm       Statement   // the initialization stm (optional)
+	Condition     Expression  // loop condition (optional, loops forever when missing)
+	PostIteration Expression  // the post iteration expression (optional)
 	Body          *BlockStm   // loop body that would be executed
 }
 
+type WhileLoopExpression struct {
+	Token     token.Token // the while token
+	Condition Expression
+	Body      *BlockStm
+}
+
+type DoWhileLoopExpression struct {
+	Token     token.Token // the do token
+	Body      *BlockStm
+	Condition Expression
+}
+
+type ForInLoopExpression struct {
+	Token    token.Token // the for token
+	Variable *Identifier // the variable bound to each element
+	Iterable Expression
+	Body     *BlockStm
+}
+
 type PostfixExpression struct {
 	Token    token.Token
 	Operator string
@@ -94,9 +150,25 @@ type IfExpression struct {
 	Token     token.Token // the if token (token.IF)
 	Condition Expression
 	Body      *BlockStm
+	ElseIf    *IfExpression // the else if (...) {} branch
 	ElseBody  *BlockStm
 }
 
+// match (value) { case 1, 2 => ...; default => ... }
+type MatchExpression struct {
+	Token   token.Token // the match token
+	Value   Expression
+	Arms    []*MatchArm
+	Default *BlockStm
+}
+
+// an arm of a match expression, it's selected when one of its patterns matches
+type MatchArm struct {
+	Token    token.Token // the case token
+	Patterns []Expression
+	Body     *BlockStm
+}
+
 type BlockStm struct {
 	Token      token.Token // the { token the starting of if block
 	Statements []Statement
@@ -104,15 +176,33 @@ type BlockStm struct {
 
 type AssignmentExpression struct {
 	Token           token.Token
-	Left            *Identifier
+	Operator        string     // = or a compound assignment operator (+=, -=, ...)
+	Left            Expression // identifier or member expression
 	AssignmentValue Expression
 }
 
+type MemberExpression struct {
+	Token    token.Token // the . or ?. token
+	Object   Expression
+	Property *Identifier
+	Optional bool // a?.b is undefined when a is undefined
+}
+
 type ArrayLiteral struct {
 	Token  token.Token //  the [ token starting the arrayLiteral
 	Values []Expression
 }
 
+type MapLiteral struct {
+	Token token.Token // the { token starting the map literal
+	Pairs []MapPair   // pairs in the order of the source
+}
+
+type MapPair struct {
+	Key   Expression
+	Value Expression
+}
+
 type IndexExpression struct {
 	Token token.Token // [ token
 	Left  Expression
@@ -135,6 +225,13 @@ func (prog *Program) TokenLiteral() string {
 	return ""
 }
 
+func (prog *Program) Pos() token.Position {
+	if len(prog.Statements) > 0 {
+		return prog.Statements[0].Pos()
+	}
+	return token.Position{}
+}
+
 func (prog *Program) ToString() string {
 	var bf bytes.Buffer
 
@@ -148,11 +245,24 @@ func (defStm *DefStatement) TokenLiteral() string {
 	return defStm.Token.Value
 }
 
+func (defStm *DefStatement) Pos() token.Position {
+	return defStm.Token.Pos
+}
+
+// IsConst reports whether the statement defines read-only bindings
+func (defStm *DefStatement) IsConst() bool {
+	return defStm.Token.Type == token.CONST
+}
+
 func (defStm *DefStatement) ToString() string {
 	var bf bytes.Buffer
 
 	bf.WriteString(defStm.TokenLiteral() + " ")
-	bf.WriteString(defStm.Name.ToString())
+	if defStm.Pattern != nil {
+		bf.WriteString(defStm.Pattern.ToString())
+	} else {
+		bf.WriteString(defStm.Name.ToString())
+	}
 	bf.WriteString(" = ")
 	if defStm.Value != nil {
 		bf.WriteString(defStm.Value.ToString())
@@ -164,6 +274,10 @@ func (defStm *DefStatement) ToString() string {
 func (b *BlockStm) TokenLiteral() string {
 	return b.Token.Value
 }
+
+func (b *BlockStm) Pos() token.Position {
+	return b.Token.Pos
+}
 func (b *BlockStm) ToString() string {
 
 	var bf bytes.Buffer
@@ -184,6 +298,10 @@ func (ifExp *IfExpression) TokenLiteral() string {
 	return ifExp.Token.Value
 }
 
+func (ifExp *IfExpression) Pos() token.Position {
+	return ifExp.Token.Pos
+}
+
 func (ifExp *IfExpression) ToString() string {
 	var bf bytes.Buffer
 
@@ -192,6 +310,11 @@ func (ifExp *IfExpression) ToString() string {
 	bf.WriteRune(' ')
 	bf.WriteString(ifExp.Body.ToString())
 
+	if ifExp.ElseIf != nil {
+		bf.WriteString("else ")
+		bf.WriteString(ifExp.ElseIf.ToString())
+	}
+
 	if ifExp.ElseBody != nil {
 		bf.WriteString("else")
 		bf.WriteString(ifExp.ElseBody.ToString())
@@ -200,10 +323,48 @@ func (ifExp *IfExpression) ToString() string {
 	return bf.String()
 }
 
+func (matchExp *MatchExpression) TokenLiteral() string {
+	return matchExp.Token.Value
+}
+
+func (matchExp *MatchExpression) Pos() token.Position {
+	return matchExp.Token.Pos
+}
+
+func (matchExp *MatchExpression) ToString() string {
+	var bf bytes.Buffer
+
+	bf.WriteString("match(")
+	bf.WriteString(matchExp.Value.ToString())
+	bf.WriteString("){")
+	for _, arm := range matchExp.Arms {
+		bf.WriteString("case ")
+		for idx, pattern := range arm.Patterns {
+			bf.WriteString(pattern.ToString())
+			if idx != len(arm.Patterns)-1 {
+				bf.WriteRune(',')
+			}
+		}
+		bf.WriteString(" => ")
+		bf.WriteString(arm.Body.ToString())
+	}
+	if matchExp.Default != nil {
+		bf.WriteString("default => ")
+		bf.WriteString(matchExp.Default.ToString())
+	}
+	bf.WriteRune('}')
+
+	return bf.String()
+}
+
 func (postfixExp *PostfixExpression) TokenLiteral() string {
 	return postfixExp.Token.Value
 }
 
+func (postfixExp *PostfixExpression) Pos() token.Position {
+	return postfixExp.Token.Pos
+}
+
 func (postfixExp *PostfixExpression) ToString() string {
 	var bf bytes.Buffer
 	bf.WriteRune('(')
@@ -215,26 +376,96 @@ func (postfixExp *PostfixExpression) ToString() string {
 func (forExp *ForLoopExpression) TokenLiteral() string {
 	return forExp.Token.Value
 }
+
+func (forExp *ForLoopExpression) Pos() token.Position {
+	return forExp.Token.Pos
+}
 func (forExp *ForLoopExpression) ToString() string {
 
 	var bf bytes.Buffer
 	bf.WriteString(forExp.TokenLiteral())
 	bf.WriteString(" (")
-	bf.WriteString(forExp.InitStm.ToString())
+	if forExp.InitStm != nil {
+		bf.WriteString(forExp.InitStm.ToString())
+	}
 	bf.WriteString("; ")
-	bf.WriteString(forExp.Condition.ToString())
+	if forExp.Condition != nil {
+		bf.WriteString(forExp.Condition.ToString())
+	}
 	bf.WriteString("; ")
-	bf.WriteString(forExp.PostIteration.ToString())
+	if forExp.PostIteration != nil {
+		bf.WriteString(forExp.PostIteration.ToString())
+	}
 	bf.WriteString(" )")
 
 	bf.WriteString(forExp.Body.ToString())
 	return bf.String()
 }
 
+func (whileExp *WhileLoopExpression) TokenLiteral() string {
+	return whileExp.Token.Value
+}
+
+func (whileExp *WhileLoopExpression) Pos() token.Position {
+	return whileExp.Token.Pos
+}
+
+func (whileExp *WhileLoopExpression) ToString() string {
+	var bf bytes.Buffer
+	bf.WriteString(whileExp.TokenLiteral())
+	bf.WriteString(" (")
+	bf.WriteString(whileExp.Condition.ToString())
+	bf.WriteString(")")
+	bf.WriteString(whileExp.Body.ToString())
+	return bf.String()
+}
+
+func (doWhileExp *DoWhileLoopExpression) TokenLiteral() string {
+	return doWhileExp.Token.Value
+}
+
+func (doWhileExp *DoWhileLoopExpression) Pos() token.Position {
+	return doWhileExp.Token.Pos
+}
+
+func (doWhileExp *DoWhileLoopExpression) ToString() string {
+	var bf bytes.Buffer
+	bf.WriteString(doWhileExp.TokenLiteral())
+	bf.WriteString(doWhileExp.Body.ToString())
+	bf.WriteString("while (")
+	bf.WriteString(doWhileExp.Condition.ToString())
+	bf.WriteString(")")
+	return bf.String()
+}
+
+func (forInExp *ForInLoopExpression) TokenLiteral() string {
+	return forInExp.Token.Value
+}
+
+func (forInExp *ForInLoopExpression) Pos() token.Position {
+	return forInExp.Token.Pos
+}
+
+func (forInExp *ForInLoopExpression) ToString() string {
+	var bf bytes.Buffer
+	bf.WriteString(forInExp.TokenLiteral())
+	bf.WriteString(" (def ")
+	bf.WriteString(forInExp.Variable.ToString())
+	bf.WriteString(" in ")
+	bf.WriteString(forInExp.Iterable.ToString())
+	bf.WriteString(")")
+	bf.WriteString(forInExp.Body.ToString())
+	return bf.String()
+}
+
 func (infixExp *InfixExpression) TokenLiteral() string {
 	return infixExp.Token.Value
 }
 
+func (infixExp *InfixExpression) Pos() token.Position {
+	return infixExp.Token.Pos
+}
+
 func (infixExp *InfixExpression) ToString() string {
 	var bf bytes.Buffer
 
@@ -250,6 +481,10 @@ func (prefixExp *PrefixExpression) TokenLiteral() string {
 	return prefixExp.Token.Value
 }
 
+func (prefixExp *PrefixExpression) Pos() token.Position {
+	return prefixExp.Token.Pos
+}
+
 func (prefixExp *PrefixExpression) ToString() string {
 	var bf bytes.Buffer
 
@@ -264,6 +499,10 @@ func (prefixExp *PrefixExpression) ToString() string {
 func (strLit *StringLiteral) TokenLiteral() string {
 	return strLit.Token.Value
 }
+
+func (strLit *StringLiteral) Pos() token.Position {
+	return strLit.Token.Pos
+}
 func (strLit *StringLiteral) ToString() string {
 	return strLit.Token.Value
 }
@@ -271,14 +510,34 @@ func (intLiteral *IntegerLiteral) TokenLiteral() string {
 	return intLiteral.Token.Value
 }
 
+func (intLiteral *IntegerLiteral) Pos() token.Position {
+	return intLiteral.Token.Pos
+}
+
 func (intLiteral *IntegerLiteral) ToString() string {
 	return intLiteral.Token.Value
 }
 
+func (floatLiteral *FloatLiteral) TokenLiteral() string {
+	return floatLiteral.Token.Value
+}
+
+func (floatLiteral *FloatLiteral) Pos() token.Position {
+	return floatLiteral.Token.Pos
+}
+
+func (floatLiteral *FloatLiteral) ToString() string {
+	return floatLiteral.Token.Value
+}
+
 func (exStm *ExpressionStatement) TokenLiteral() string {
 	return exStm.Token.Value
 }
 
+func (exStm *ExpressionStatement) Pos() token.Position {
+	return exStm.Token.Pos
+}
+
 func (exStm *ExpressionStatement) ToString() string {
 	var bf bytes.Buffer
 	if exStm.Expression != nil {
@@ -291,6 +550,29 @@ func (fnCall *FunctionCall) TokenLiteral() string {
 	return fnCall.Token.Value
 }
 
+func (fnCall *FunctionCall) Pos() token.Position {
+	return fnCall.Token.Pos
+}
+
+// the position where the expression starts in the source, the position of the
+// calls, members, indexes and binary expressions is the one of their operator
+func StartPos(exp Expression) token.Position {
+	switch exp := exp.(type) {
+	case *FunctionCall:
+		return StartPos(exp.Function)
+	case *MemberExpression:
+		return StartPos(exp.Object)
+	case *IndexExpression:
+		return StartPos(exp.Left)
+	case *InfixExpression:
+		return StartPos(exp.Left)
+	case *PostfixExpression:
+		return StartPos(exp.Left)
+	default:
+		return exp.Pos()
+	}
+}
+
 func (fnCall *FunctionCall) ToString() string {
 	var bf bytes.Buffer
 	bf.WriteString(fnCall.Function.ToString())
@@ -311,35 +593,115 @@ func (fnExp *FunctionExp) TokenLiteral() string {
 	return fnExp.Token.Value
 }
 
-func (fnExp *FunctionExp) ToString() string {
-	var bf bytes.Buffer
+func (fnExp *FunctionExp) Pos() token.Position {
+	return fnExp.Token.Pos
+}
 
-	bf.WriteString(fnExp.TokenLiteral())
-	bf.WriteRune(' ')
-	bf.WriteString(fnExp.Name.ToString())
-	bf.WriteRune('(')
+// the param at the position as written in the source, a name or a destructured pattern
+func (fnExp *FunctionExp) ParamString(idx int) string {
+	if idx < len(fnExp.Patterns) && fnExp.Patterns[idx] != nil {
+		return fnExp.Patterns[idx].ToString()
+	}
+	return fnExp.Parameters[idx].ToString()
+}
 
-	for idx, iden := range fnExp.Parameters {
-		bf.WriteString(iden.ToString())
+// the params between parentheses with their default values and the rest param
+func (fnExp *FunctionExp) paramList() string {
+	var bf bytes.Buffer
+	bf.WriteRune('(')
+	for idx := range fnExp.Parameters {
+		bf.WriteString(fnExp.ParamString(idx))
+		if idx < len(fnExp.Defaults) && fnExp.Defaults[idx] != nil {
+			bf.WriteRune('=')
+			bf.WriteString(fnExp.Defaults[idx].ToString())
+		}
 		if idx != len(fnExp.Parameters)-1 {
 			bf.WriteRune(',')
 		}
 	}
+	if fnExp.Rest != nil {
+		if len(fnExp.Parameters) > 0 {
+			bf.WriteRune(',')
+		}
+		bf.WriteString("...")
+		bf.WriteString(fnExp.Rest.ToString())
+	}
 	bf.WriteRune(')')
+	return bf.String()
+}
+
+func (fnExp *FunctionExp) ToString() string {
+	var bf bytes.Buffer
+
+	if fnExp.Token.Type != token.ARROW {
+		bf.WriteString(fnExp.TokenLiteral())
+		bf.WriteRune(' ')
+	}
+	if fnExp.Name != nil {
+		bf.WriteString(fnExp.Name.ToString())
+	}
+	bf.WriteString(fnExp.paramList())
+	if fnExp.Token.Type == token.ARROW {
+		bf.WriteString(" => ")
+	}
 	bf.WriteString(fnExp.FnBody.ToString())
 	return bf.String()
 }
+func (namedArg *NamedArgument) TokenLiteral() string {
+	return namedArg.Token.Value
+}
+
+func (namedArg *NamedArgument) Pos() token.Position {
+	return namedArg.Token.Pos
+}
+
+func (namedArg *NamedArgument) ToString() string {
+	return namedArg.Name.ToString() + "=" + namedArg.Value.ToString()
+}
+
+func (spread *SpreadExpression) TokenLiteral() string {
+	return spread.Token.Value
+}
+
+func (spread *SpreadExpression) Pos() token.Position {
+	return spread.Token.Pos
+}
+
+func (spread *SpreadExpression) ToString() string {
+	return "..." + spread.Value.ToString()
+}
+
 func (b *BooleanExp) TokenLiteral() string {
 	return b.Token.Value
 }
 
+func (b *BooleanExp) Pos() token.Position {
+	return b.Token.Pos
+}
+
 func (b *BooleanExp) ToString() string {
 	return b.Token.Value
 }
+func (null *NullLiteral) TokenLiteral() string {
+	return null.Token.Value
+}
+
+func (null *NullLiteral) Pos() token.Position {
+	return null.Token.Pos
+}
+
+func (null *NullLiteral) ToString() string {
+	return null.Token.Value
+}
+
 func (reStm *ReturnStatement) TokenLiteral() string {
 	return reStm.Token.Value
 }
 
+func (reStm *ReturnStatement) Pos() token.Position {
+	return reStm.Token.Pos
+}
+
 func (resStm *ReturnStatement) ToString() string {
 	var bf bytes.Buffer
 	bf.WriteString(resStm.TokenLiteral())
@@ -350,10 +712,38 @@ func (resStm *ReturnStatement) ToString() string {
 	bf.WriteString(";")
 	return bf.String()
 }
+func (breakStm *BreakStatement) TokenLiteral() string {
+	return breakStm.Token.Value
+}
+
+func (breakStm *BreakStatement) Pos() token.Position {
+	return breakStm.Token.Pos
+}
+
+func (breakStm *BreakStatement) ToString() string {
+	return breakStm.TokenLiteral() + ";"
+}
+
+func (continueStm *ContinueStatement) TokenLiteral() string {
+	return continueStm.Token.Value
+}
+
+func (continueStm *ContinueStatement) Pos() token.Position {
+	return continueStm.Token.Pos
+}
+
+func (continueStm *ContinueStatement) ToString() string {
+	return continueStm.TokenLiteral() + ";"
+}
+
 func (ident *Identifier) TokenLiteral() string {
 	return ident.Token.Value
 }
 
+func (ident *Identifier) Pos() token.Position {
+	return ident.Token.Pos
+}
+
 func (ident *Identifier) ToString() string {
 	return ident.Value
 }
@@ -362,20 +752,47 @@ func (assignExpr *AssignmentExpression) TokenLiteral() string {
 	return assignExpr.Token.Value
 }
 
+func (assignExpr *AssignmentExpression) Pos() token.Position {
+	return assignExpr.Token.Pos
+}
+
 func (assignExpr *AssignmentExpression) ToString() string {
 
 	var bf bytes.Buffer
 	bf.WriteString(assignExpr.Left.ToString())
-	bf.WriteString(" = ")
+	bf.WriteString(" " + assignExpr.Operator + " ")
 	bf.WriteString(assignExpr.AssignmentValue.ToString())
 
 	return bf.String()
 }
 
+func (member *MemberExpression) TokenLiteral() string {
+	return member.Token.Value
+}
+
+func (member *MemberExpression) Pos() token.Position {
+	return member.Token.Pos
+}
+
+func (member *MemberExpression) ToString() string {
+	var bf bytes.Buffer
+	bf.WriteString(member.Object.ToString())
+	if member.Optional {
+		bf.WriteRune('?')
+	}
+	bf.WriteRune('.')
+	bf.WriteString(member.Property.ToString())
+	return bf.String()
+}
+
 func (arr *ArrayLiteral) TokenLiteral() string {
 	return arr.Token.Value
 }
 
+func (arr *ArrayLiteral) Pos() token.Position {
+	return arr.Token.Pos
+}
+
 func (arr *ArrayLiteral) ToString() string {
 	var bf bytes.Buffer
 	bf.WriteRune('[')
@@ -385,6 +802,30 @@ func (arr *ArrayLiteral) ToString() string {
 			bf.WriteRune(',')
 		}
 	}
+	bf.WriteRune(']')
+	return bf.String()
+}
+
+func (mapLit *MapLiteral) TokenLiteral() string {
+	return mapLit.Token.Value
+}
+
+func (mapLit *MapLiteral) Pos() token.Position {
+	return mapLit.Token.Pos
+}
+
+func (mapLit *MapLiteral) ToString() string {
+	var bf bytes.Buffer
+	bf.WriteRune('{')
+	for i, pair := range mapLit.Pairs {
+		bf.WriteString(pair.Key.ToString())
+		bf.WriteRune(':')
+		bf.WriteString(pair.Value.ToString())
+		if i != len(mapLit.Pairs)-1 {
+			bf.WriteRune(',')
+		}
+	}
+	bf.WriteRune('}')
 	return bf.String()
 }
 
@@ -400,32 +841,74 @@ func (indexExp *IndexExpression) TokenLiteral() string {
 	return indexExp.Token.Value
 }
 
+func (indexExp *IndexExpression) Pos() token.Position {
+	return indexExp.Token.Pos
+}
+
 func (class *ClassLiteral) TokenLiteral() string {
 	return class.Token.Value
 }
 
+func (class *ClassLiteral) Pos() token.Position {
+	return class.Token.Pos
+}
+
 func (class *ClassLiteral) ToString() string {
-	return "TODO: IMPLEMENT TO STRING FOR THE CLASS NODE"
+	var bf bytes.Buffer
+
+	bf.WriteString(class.TokenLiteral())
+	bf.WriteRune(' ')
+	bf.WriteString(class.ClassName.ToString())
+	bf.WriteRune('{')
+
+	for _, field := range class.DataMembers {
+		bf.WriteString(field.ToString())
+	}
+
+	if class.Constructor != nil {
+		bf.WriteString(class.Constructor.TokenLiteral())
+		bf.WriteString(class.Constructor.paramList())
+		bf.WriteString(class.Constructor.FnBody.ToString())
+	}
+
+	for _, method := range class.Methods {
+		bf.WriteString(method.ToString())
+	}
+
+	bf.WriteRune('}')
+	return bf.String()
 }
 
 // expression implementations
-func (postfixExp *PostfixExpression) expressionNode()    {}
-func (forExp *ForLoopExpression) expressionNode()        {}
-func (infixExp *InfixExpression) expressionNode()        {}
-func (prefixExp *PrefixExpression) expressionNode()      {}
-func (strLit *StringLiteral) expressionNode()            {}
-func (intLiteral *IntegerLiteral) expressionNode()       {}
-func (fnCall *FunctionCall) expressionNode()             {}
-func (fnExp *FunctionExp) expressionNode()               {}
-func (b *BooleanExp) expressionNode()                    {}
-func (ident *Identifier) expressionNode()                {}
-func (assignExpr *AssignmentExpression) expressionNode() {}
-func (arr *ArrayLiteral) expressionNode()                {}
-func (indexExp *IndexExpression) expressionNode()        {}
-func (class *ClassLiteral) expressionNode()              {}
+func (postfixExp *PostfixExpression) expressionNode()     {}
+func (forExp *ForLoopExpression) expressionNode()         {}
+func (whileExp *WhileLoopExpression) expressionNode()     {}
+func (doWhileExp *DoWhileLoopExpression) expressionNode() {}
+func (forInExp *ForInLoopExpression) expressionNode()     {}
+func (matchExp *MatchExpression) expressionNode()         {}
+func (infixExp *InfixExpression) expressionNode()         {}
+func (prefixExp *PrefixExpression) expressionNode()       {}
+func (strLit *StringLiteral) expressionNode()             {}
+func (intLiteral *IntegerLiteral) expressionNode()        {}
+func (floatLiteral *FloatLiteral) expressionNode()        {}
+func (fnCall *FunctionCall) expressionNode()              {}
+func (fnExp *FunctionExp) expressionNode()                {}
+func (spread *SpreadExpression) expressionNode()          {}
+func (namedArg *NamedArgument) expressionNode()           {}
+func (b *BooleanExp) expressionNode()                     {}
+func (null *NullLiteral) expressionNode()                 {}
+func (ident *Identifier) expressionNode()                 {}
+func (assignExpr *AssignmentExpression) expressionNode()  {}
+func (member *MemberExpression) expressionNode()          {}
+func (arr *ArrayLiteral) expressionNode()                 {}
+func (indexExp *IndexExpression) expressionNode()         {}
+func (mapLit *MapLiteral) expressionNode()                {}
+func (class *ClassLiteral) expressionNode()               {}
 
 // statemetns implmentations
-func (b *BlockStm) statementNode()                {}
-func (defStm *DefStatement) statementNode()       {}
-func (exStm *ExpressionStatement) statementNode() {}
-func (reStm *ReturnStatement) statementNode()     {}
+func (b *BlockStm) statementNode()                    {}
+func (defStm *DefStatement) statementNode()           {}
+func (exStm *ExpressionStatement) statementNode()     {}
+func (reStm *ReturnStatement) statementNode()         {}
+func (breakStm *BreakStatement) statementNode()       {}
+func (continueStm *ContinueStatement) statementNode() {}
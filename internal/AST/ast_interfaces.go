@@ -1,9 +1,12 @@
 package ast
 
+import "github.com/houcine7/JIPL/internal/token"
+
 // the nodes of the	AST tree
 type Node interface {
 	TokenLiteral() string
 	ToString() string
+	Pos() token.Position // the position of the token of the node
 }
 
 /*
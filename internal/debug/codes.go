@@ -0,0 +1,14 @@
+package debug
+
+// the codes of the errors shown by the diagnostics
+// E1xx are parsing errors and E2xx are runtime errors
+const (
+	SYNTAX_ERROR       = "E100" // the parsing errors without a specific code
+	UNEXPECTED_TOKEN   = "E101"
+	MISSING_EXPRESSION = "E102"
+	CONSTANT_ERROR     = "E103"
+
+	RUNTIME_ERROR  = "E200" // the runtime errors without a specific code
+	TYPE_MISMATCH  = "E201"
+	UNDEFINED_NAME = "E202"
+)
@@ -1,17 +1,39 @@
 package debug
 
+import (
+	"fmt"
+
+	"github.com/houcine7/JIPL/internal/token"
+)
+
 type Error struct {
-	Msg string
+	Msg   string
+	Pos   token.Position // where the error occurred, the zero Position when it's unknown
+	Code  string         // one of the error codes, empty for the generic runtime errors
+	Stack []Frame        // the calls being evaluated when the error occurred, the innermost is the last
+}
+
+// a call of a function of the program
+type Frame struct {
+	Function string         // the name of the called function
+	Pos      token.Position // the position where the called expression starts
 }
 
 func (err *Error) Error() string {
-	return err.Msg
+	if !err.Pos.IsValid() {
+		return err.Msg
+	}
+	return fmt.Sprintf("%s: %s", err.Pos, err.Msg)
 }
 
 func NewError(msg string) *Error {
 	return &Error{Msg: msg}
 }
 
+func NewCodedError(code, msg string) *Error {
+	return &Error{Msg: msg, Code: code}
+}
+
 var (
 	NOERROR = &Error{Msg: ""}
 )
@@ -0,0 +1,200 @@
+package diagnostics
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/houcine7/JIPL/internal/debug"
+	"github.com/houcine7/JIPL/internal/parser"
+	"github.com/houcine7/JIPL/internal/token"
+)
+
+// a parsing or runtime error ready to be shown to the user
+type Diagnostic struct {
+	Code    string
+	Message string
+	Pos     token.Position
+	Hints   []string
+	Stack   []debug.Frame // the calls leading to a runtime error, the innermost is the last
+}
+
+func FromParserError(err *parser.Error) Diagnostic {
+	code := err.Code
+	if code == "" {
+		code = debug.SYNTAX_ERROR
+	}
+	diag := Diagnostic{Code: code, Message: err.Message, Pos: err.Pos()}
+
+	switch code {
+	case debug.UNEXPECTED_TOKEN:
+		if err.Token.Type == token.FILE_ENDED {
+			diag.Hints = append(diag.Hints, "the input ended before the end of the statement, check for a missing closing bracket")
+		}
+	case debug.MISSING_EXPRESSION:
+		switch err.Token.Type {
+		case token.RP, token.RB, token.RCB, token.S_COLON, token.COMMA:
+			diag.Hints = append(diag.Hints, fmt.Sprintf("a value is missing before %q", err.Token.Type.String()))
+		}
+	case debug.CONSTANT_ERROR:
+		diag.Hints = append(diag.Hints, "use def instead of const to define a variable that can be reassigned")
+	}
+	return diag
+}
+
+func FromRuntimeError(err *debug.Error) Diagnostic {
+	code := err.Code
+	if code == "" {
+		code = debug.RUNTIME_ERROR
+	}
+	diag := Diagnostic{Code: code, Message: err.Msg, Pos: err.Pos, Stack: err.Stack}
+
+	switch code {
+	case debug.TYPE_MISMATCH:
+		diag.Hints = append(diag.Hints, "convert one of the operands first, with int() or float() for the numbers")
+	case debug.UNDEFINED_NAME:
+		diag.Hints = append(diag.Hints, "define the name with def or const before using it")
+	}
+	return diag
+}
+
+/*
+* Renders the diagnostics with the source lines they point to, the runtime errors
+* raised inside functions start with the traceback of the calls:
+*
+* Traceback (most recent call last):
+*   File "main.jipl", line 4, in <main>
+*     add(a, "x");
+*   File "main.jipl", line 2, in add
+*     return a + b;
+* error[E201]: type mismatch: INTEGER + STRING
+*  --> main.jipl:2:11
+*   |
+* 2 | def b = a + "x";
+*   |           ^
+*   = hint: convert one of the operands first, with int() or float() for the numbers
+ */
+type Renderer struct {
+	sources map[string]string // the content of the sources by file name
+}
+
+func NewRenderer() *Renderer {
+	return &Renderer{sources: make(map[string]string)}
+}
+
+// registers the content of a source under the file name given to its lexer
+func (r *Renderer) AddSource(file, content string) {
+	r.sources[file] = content
+}
+
+func (r *Renderer) Render(diag Diagnostic) string {
+	var bf bytes.Buffer
+
+	bf.WriteString(r.traceback(diag))
+	bf.WriteString(fmt.Sprintf("error[%s]: %s\n", diag.Code, diag.Message))
+
+	line, ok := r.sourceLine(diag.Pos)
+	gutter := strings.Repeat(" ", len(strconv.Itoa(diag.Pos.Line)))
+
+	if diag.Pos.IsValid() {
+		bf.WriteString(fmt.Sprintf("%s--> %s\n", gutter, diag.Pos))
+	}
+	if ok {
+		bf.WriteString(fmt.Sprintf("%s |\n", gutter))
+		bf.WriteString(fmt.Sprintf("%d | %s\n", diag.Pos.Line, line))
+		bf.WriteString(fmt.Sprintf("%s | %s^\n", gutter, caretPadding(line, diag.Pos.Column)))
+	}
+	for _, hint := range diag.Hints {
+		bf.WriteString(fmt.Sprintf("%s = hint: %s\n", gutter, hint))
+	}
+	return bf.String()
+}
+
+// the frames repeated more than this are collapsed in the traceback
+const MAX_REPEATED_FRAMES = 3
+
+// the traceback of the calls, every call is shown in the function that made it
+func (r *Renderer) traceback(diag Diagnostic) string {
+	if len(diag.Stack) == 0 {
+		return ""
+	}
+
+	type entry struct {
+		pos      token.Position
+		function string
+	}
+	entries := make([]entry, 0, len(diag.Stack)+1)
+	caller := "<main>"
+	for _, frame := range diag.Stack {
+		entries = append(entries, entry{pos: frame.Pos, function: caller})
+		caller = frame.Function
+	}
+	entries = append(entries, entry{pos: diag.Pos, function: caller})
+
+	var bf bytes.Buffer
+	bf.WriteString("Traceback (most recent call last):\n")
+
+	repeated := 0
+	for idx, e := range entries {
+		if idx > 0 && e == entries[idx-1] {
+			repeated++
+			if repeated >= MAX_REPEATED_FRAMES {
+				continue
+			}
+		} else {
+			r.writeRepeated(&bf, repeated)
+			repeated = 0
+		}
+
+		file := e.pos.File
+		if file == "" {
+			file = "<input>"
+		}
+		bf.WriteString(fmt.Sprintf("  File %q, line %d, in %s\n", file, e.pos.Line, e.function))
+		if line, ok := r.sourceLine(e.pos); ok {
+			bf.WriteString(fmt.Sprintf("    %s\n", strings.TrimSpace(line)))
+		}
+	}
+	r.writeRepeated(&bf, repeated)
+	return bf.String()
+}
+
+func (r *Renderer) writeRepeated(bf *bytes.Buffer, repeated int) {
+	if repeated >= MAX_REPEATED_FRAMES {
+		bf.WriteString(fmt.Sprintf("  [previous line repeated %d more times]\n", repeated-MAX_REPEATED_FRAMES+1))
+	}
+}
+
+// the line of the source containing the position
+func (r *Renderer) sourceLine(pos token.Position) (string, bool) {
+	source, ok := r.sources[pos.File]
+	if !ok || !pos.IsValid() || pos.Offset > len(source) {
+		return "", false
+	}
+
+	start := strings.LastIndexByte(source[:pos.Offset], '\n') + 1
+	end := strings.IndexByte(source[pos.Offset:], '\n')
+	if end == -1 {
+		end = len(source)
+	} else {
+		end += pos.Offset
+	}
+	return strings.TrimRight(source[start:end], "\r"), true
+}
+
+// the spaces before the caret, the tabs of the line are kept to stay aligned
+func caretPadding(line string, column int) string {
+	var bf bytes.Buffer
+	for idx, char := range []rune(line) {
+		if idx >= column-1 {
+			break
+		}
+		if char == '\t' {
+			bf.WriteRune('\t')
+		} else {
+			bf.WriteRune(' ')
+		}
+	}
+	return bf.String()
+}
@@ -0,0 +1,142 @@
+package diagnostics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/houcine7/JIPL/internal/debug"
+	"github.com/houcine7/JIPL/internal/lexer"
+	"github.com/houcine7/JIPL/internal/parser"
+	"github.com/houcine7/JIPL/internal/runtime"
+	"github.com/houcine7/JIPL/internal/token"
+	"github.com/houcine7/JIPL/internal/types"
+)
+
+func TestRenderParserError(t *testing.T) {
+	source := "def a = 1;\ndef b = (a + ;"
+	p := parser.InitParser(lexer.InitFileLexer("main.jipl", source))
+	p.Parse()
+	if len(p.Errors()) == 0 {
+		t.Fatalf("expected a parsing error")
+	}
+
+	renderer := NewRenderer()
+	renderer.AddSource("main.jipl", source)
+	rendered := renderer.Render(FromParserError(p.Errors()[0]))
+
+	expected := "error[E102]: expected an expression instead got \";\"\n" +
+		" --> main.jipl:2:14\n" +
+		"  |\n" +
+		"2 | def b = (a + ;\n" +
+		"  |              ^\n" +
+		"  = hint: a value is missing before \";\"\n"
+	if rendered != expected {
+		t.Fatalf("wrong rendering expected:\n%s\ninstead got:\n%s", expected, rendered)
+	}
+}
+
+func TestRenderRuntimeError(t *testing.T) {
+	rendered := renderSource(t, "def a = 1;\n\tdef b = a + \"x\";\n")
+
+	expected := "error[E201]: type mismatch: INTEGER + STRING\n" +
+		" --> main.jipl:2:12\n" +
+		"  |\n" +
+		"2 | \tdef b = a + \"x\";\n" +
+		"  | \t          ^\n" +
+		"  = hint: convert one of the operands first, with int() or float() for the numbers\n"
+	if rendered != expected {
+		t.Fatalf("wrong rendering expected:\n%s\ninstead got:\n%s", expected, rendered)
+	}
+}
+
+func TestRenderTraceback(t *testing.T) {
+	source := "function add(a, b) {\n  return a + b;\n}\ndef twice = (x) => add(x, \"x\");\ntwice(1);\n"
+	rendered := renderSource(t, source)
+
+	expected := "Traceback (most recent call last):\n" +
+		"  File \"main.jipl\", line 5, in <main>\n" +
+		"    twice(1);\n" +
+		"  File \"main.jipl\", line 4, in twice\n" +
+		"    def twice = (x) => add(x, \"x\");\n" +
+		"  File \"main.jipl\", line 2, in add\n" +
+		"    return a + b;\n" +
+		"error[E201]: type mismatch: INTEGER + STRING\n" +
+		" --> main.jipl:2:12\n" +
+		"  |\n" +
+		"2 |   return a + b;\n" +
+		"  |            ^\n" +
+		"  = hint: convert one of the operands first, with int() or float() for the numbers\n"
+	if rendered != expected {
+		t.Fatalf("wrong rendering expected:\n%s\ninstead got:\n%s", expected, rendered)
+	}
+}
+
+func TestRenderRepeatedFrames(t *testing.T) {
+	source := "def f = function(n) {\n  if (n == 0) { return missing; }\n  return f(n - 1);\n};\nf(10);\n"
+	rendered := renderSource(t, source)
+
+	expected := "Traceback (most recent call last):\n" +
+		"  File \"main.jipl\", line 5, in <main>\n" +
+		"    f(10);\n" +
+		"  File \"main.jipl\", line 3, in f\n" +
+		"    return f(n - 1);\n" +
+		"  File \"main.jipl\", line 3, in f\n" +
+		"    return f(n - 1);\n" +
+		"  File \"main.jipl\", line 3, in f\n" +
+		"    return f(n - 1);\n" +
+		"  [previous line repeated 7 more times]\n" +
+		"  File \"main.jipl\", line 2, in f\n" +
+		"    if (n == 0) { return missing; }\n" +
+		"error[E202]: identifier not found: missing\n"
+	if !strings.HasPrefix(rendered, expected) {
+		t.Fatalf("wrong rendering expected to start with:\n%s\ninstead got:\n%s", expected, rendered)
+	}
+}
+
+func TestRenderWithoutSource(t *testing.T) {
+	rendered := NewRenderer().Render(FromRuntimeError(debug.NewError("division by zero")))
+	if rendered != "error[E200]: division by zero\n" {
+		t.Fatalf("wrong rendering got %q", rendered)
+	}
+
+	diag := Diagnostic{Code: debug.RUNTIME_ERROR, Message: "boom", Pos: token.Position{File: "other.jipl", Line: 3, Column: 1}}
+	rendered = NewRenderer().Render(diag)
+	if rendered != "error[E200]: boom\n --> other.jipl:3:1\n" {
+		t.Fatalf("wrong rendering got %q", rendered)
+	}
+}
+
+func TestTokenTypeNames(t *testing.T) {
+	tests := []struct {
+		tokenType token.TokenType
+		expected  string
+	}{
+		{token.RP, ")"},
+		{token.IDENTIFIER, "identifier"},
+		{token.FILE_ENDED, "end of input"},
+		{token.NULLISH, "??"},
+		{token.FUNCTION, "function"},
+	}
+	for _, test := range tests {
+		if test.tokenType.String() != test.expected {
+			t.Fatalf("wrong name for the token type %d expected %s instead got %s", int(test.tokenType), test.expected, test.tokenType.String())
+		}
+	}
+}
+
+// evaluates the source as the file main.jipl and renders its runtime error
+func renderSource(t *testing.T, source string) string {
+	p := parser.InitParser(lexer.InitFileLexer("main.jipl", source))
+	program := p.Parse()
+	if len(p.Errors()) != 0 {
+		t.Fatalf("unexpected parsing errors %v", p.Errors())
+	}
+	_, err := runtime.Eval(program, types.NewContext())
+	if err == debug.NOERROR {
+		t.Fatalf("expected a runtime error")
+	}
+
+	renderer := NewRenderer()
+	renderer.AddSource("main.jipl", source)
+	return renderer.Render(FromRuntimeError(err))
+}
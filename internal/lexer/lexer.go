@@ -1,6 +1,7 @@
 package lexer
 
 import (
+	"strings"
 	"unicode/utf8"
 
 	"github.com/houcine7/JIPL/internal/token"
@@ -9,20 +10,35 @@ import (
 
 type Lexer struct {
 	input      string // the string to tokenize
+	file       string // the name of the source file, empty for the repl input
 	currentPos int    // points to the current position in the input
 	readPos    int    // current read position after the current char
 	char       rune   // the current char (byte as the binary representation of )
+	line       int    // the line of the current char
+	column     int    // the column of the current char
 }
 
 func InitLexer(input string) *Lexer {
-	l := &Lexer{input: input}
+	return InitFileLexer("", input)
+}
+
+// a lexer for the content of a file, the file name is part of the token positions
+func InitFileLexer(file, input string) *Lexer {
+	l := &Lexer{input: input, file: file, line: 1}
 	l.readChar() // READ FIRST CHAR
 	return l
 }
 
 func (l *Lexer) NextToken() token.Token {
-	var tok token.Token
 	l.ignoreWhiteSpace()
+	pos := l.position()
+	tok := l.nextToken()
+	tok.Pos = pos
+	return tok
+}
+
+func (l *Lexer) nextToken() token.Token {
+	var tok token.Token
 
 	switch l.char {
 	case '=':
@@ -30,6 +46,10 @@ func (l *Lexer) NextToken() token.Token {
 			prev := l.char
 			l.readChar()
 			tok = token.CreateToken(token.EQUAL, string(prev)+string(l.char))
+		} else if l.peek() == '>' {
+			prev := l.char
+			l.readChar()
+			tok = token.CreateToken(token.ARROW, string(prev)+string(l.char))
 		} else {
 			tok = token.CreateToken(token.ASSIGN, string(l.char))
 		}
@@ -50,11 +70,27 @@ func (l *Lexer) NextToken() token.Token {
 		} else {
 			tok = token.CreateToken(token.ILLEGAL, string(l.char))
 		}
+	case '?':
+		if l.peek() == '?' {
+			prev := l.char
+			l.readChar()
+			tok = token.CreateToken(token.NULLISH, string(prev)+string(l.char))
+		} else if l.peek() == '.' {
+			prev := l.char
+			l.readChar()
+			tok = token.CreateToken(token.OPT_DOT, string(prev)+string(l.char))
+		} else {
+			tok = token.CreateToken(token.ILLEGAL, string(l.char))
+		}
 	case '+':
 		if l.peek() == '+' {
 			prev := l.char
 			l.readChar()
 			tok = token.CreateToken(token.INCREMENT, string(prev)+string(l.char))
+		} else if l.peek() == '=' {
+			prev := l.char
+			l.readChar()
+			tok = token.CreateToken(token.PLUS_ASSIGN, string(prev)+string(l.char))
 		} else {
 			tok = token.CreateToken(token.PLUS, string(l.char))
 		}
@@ -63,15 +99,37 @@ func (l *Lexer) NextToken() token.Token {
 			prev := l.char
 			l.readChar()
 			tok = token.CreateToken(token.DECREMENT, string(l.char)+string(prev))
+		} else if l.peek() == '=' {
+			prev := l.char
+			l.readChar()
+			tok = token.CreateToken(token.MINUS_ASSIGN, string(prev)+string(l.char))
 		} else {
 			tok = token.CreateToken(token.MINUS, string(l.char))
 		}
 	case '/':
-		tok = token.CreateToken(token.SLASH, string(l.char))
+		if l.peek() == '=' {
+			prev := l.char
+			l.readChar()
+			tok = token.CreateToken(token.SLASH_ASSIGN, string(prev)+string(l.char))
+		} else {
+			tok = token.CreateToken(token.SLASH, string(l.char))
+		}
 	case '%':
-		tok = token.CreateToken(token.MODULO, string(l.char))
+		if l.peek() == '=' {
+			prev := l.char
+			l.readChar()
+			tok = token.CreateToken(token.MODULO_ASSIGN, string(prev)+string(l.char))
+		} else {
+			tok = token.CreateToken(token.MODULO, string(l.char))
+		}
 	case '*':
-		tok = token.CreateToken(token.STAR, string(l.char))
+		if l.peek() == '=' {
+			prev := l.char
+			l.readChar()
+			tok = token.CreateToken(token.STAR_ASSIGN, string(prev)+string(l.char))
+		} else {
+			tok = token.CreateToken(token.STAR, string(l.char))
+		}
 	case '!':
 		if l.peek() == '=' {
 			prev := l.char
@@ -112,6 +170,16 @@ func (l *Lexer) NextToken() token.Token {
 		tok = token.CreateToken(token.COMMA, string(l.char))
 	case ';':
 		tok = token.CreateToken(token.S_COLON, string(l.char))
+	case '.':
+		if l.peek() == '.' && l.peekAt(1) == '.' {
+			l.readChar()
+			l.readChar()
+			tok = token.CreateToken(token.ELLIPSIS, "...")
+		} else {
+			tok = token.CreateToken(token.DOT, string(l.char))
+		}
+	case ':':
+		tok = token.CreateToken(token.COLON, string(l.char))
 	case '"':
 		tok = token.CreateToken(token.STRING, l.ReadString())
 	case 0:
@@ -123,7 +191,11 @@ func (l *Lexer) NextToken() token.Token {
 			return tok
 		} else if utils.IsDigit(l.char) {
 			num := l.ReadNumber()
-			tok = token.CreateToken(token.INT, num)
+			if strings.ContainsAny(num, ".eE") {
+				tok = token.CreateToken(token.FLOAT, num)
+			} else {
+				tok = token.CreateToken(token.INT, num)
+			}
 			return tok // this prevents calling read char which is already done with the method ReadNumber()
 		} else {
 			tok = token.CreateToken(token.ILLEGAL, string(l.char))
@@ -136,9 +208,16 @@ func (l *Lexer) NextToken() token.Token {
 
 // HELPER FUNCTIONS
 func (l *Lexer) readChar() {
+	if l.char == '\n' {
+		l.line++
+		l.column = 1
+	} else if l.currentPos < len(l.input) || l.column == 0 {
+		l.column++ // stops at the end of the input
+	}
 
-	if l.readPos >= utf8.RuneCount([]byte(l.input)) {
+	if l.readPos >= len(l.input) {
 		l.char = 0 // SET THE CURRENT CHAR TO NUL CHARACTER (TO INDICATE THE TERMINATION OF THE STRING)
+		l.currentPos = len(l.input)
 	} else {
 		r, size := utf8.DecodeRuneInString(l.input[l.readPos:])
 		l.char = r
@@ -147,6 +226,11 @@ func (l *Lexer) readChar() {
 	}
 }
 
+// the position of the current char
+func (l *Lexer) position() token.Position {
+	return token.Position{File: l.file, Line: l.line, Column: l.column, Offset: l.currentPos}
+}
+
 // read string literals
 func (l *Lexer) ReadString() string {
 	currPosition := l.currentPos + 1
@@ -160,12 +244,20 @@ func (l *Lexer) ReadString() string {
 }
 
 func (l *Lexer) peek() rune {
-	if l.readPos >= utf8.RuneCount([]byte(l.input)) {
+	if l.readPos >= len(l.input) {
 		return 0
 	}
 	return rune(l.input[l.readPos])
 }
 
+// peeks the character at offset positions after the next one
+func (l *Lexer) peekAt(offset int) rune {
+	if l.readPos+offset >= len(l.input) {
+		return 0
+	}
+	return rune(l.input[l.readPos+offset])
+}
+
 func (l *Lexer) ReadIdentifier() string {
 	currPosition := l.currentPos
 
@@ -180,14 +272,38 @@ func (l *Lexer) ReadIdentifier() string {
 	return l.input[currPosition:l.currentPos]
 }
 
+// reads integers (42) and floats (3.14, 1e-9, 2.5E3)
 func (l *Lexer) ReadNumber() string {
 	currentPos := l.currentPos
-	for utils.IsDigit(l.char) {
+	l.readDigits()
+
+	// fraction part, a dot not followed by a digit is left for member access
+	if l.char == '.' && utils.IsDigit(l.peek()) {
 		l.readChar()
+		l.readDigits()
+	}
+
+	// exponent part
+	if l.char == 'e' || l.char == 'E' {
+		next := l.peek()
+		if utils.IsDigit(next) ||
+			((next == '+' || next == '-') && utils.IsDigit(l.peekAt(1))) {
+			l.readChar() // e
+			if l.char == '+' || l.char == '-' {
+				l.readChar()
+			}
+			l.readDigits()
+		}
 	}
 	return l.input[currentPos:l.currentPos]
 }
 
+func (l *Lexer) readDigits() {
+	for utils.IsDigit(l.char) {
+		l.readChar()
+	}
+}
+
 func (l *Lexer) ignoreWhiteSpace() {
 	for l.char == ' ' || l.char == '\t' || l.char == '\n' || l.char == '\r' {
 		l.readChar()
@@ -77,6 +77,95 @@ func TestNext3(t *testing.T) {
 	}
 }
 
+func TestCompoundAssignment(t *testing.T) {
+	myLexer := InitLexer(Mock3)
+
+	for i, et := range NextData3 {
+		calculatedToken := myLexer.NextToken()
+
+		if et.expectedTokenType != calculatedToken.Type {
+			t.Fatalf("tests index %d -> tokenType wrong, expected:[%d] and got:[%d]",
+				i, et.expectedTokenType, calculatedToken.Type)
+		}
+
+		if et.expectedValue != calculatedToken.Value {
+			t.Fatalf("tests index %d -> token value is wrong, expected:[%q] and got:[%q]",
+				i, et.expectedValue, calculatedToken.Value)
+		}
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	myLexer := InitLexer(Mock4)
+
+	for i, et := range NextData4 {
+		calculatedToken := myLexer.NextToken()
+
+		if et.expectedTokenType != calculatedToken.Type {
+			t.Fatalf("tests index %d -> tokenType wrong, expected:[%d] and got:[%d]",
+				i, et.expectedTokenType, calculatedToken.Type)
+		}
+
+		if et.expectedValue != calculatedToken.Value {
+			t.Fatalf("tests index %d -> token value is wrong, expected:[%q] and got:[%q]",
+				i, et.expectedValue, calculatedToken.Value)
+		}
+	}
+}
+
+func TestFunctionTokens(t *testing.T) {
+	myLexer := InitLexer(Mock5)
+
+	for i, et := range NextData5 {
+		calculatedToken := myLexer.NextToken()
+
+		if et.expectedTokenType != calculatedToken.Type {
+			t.Fatalf("tests index %d -> tokenType wrong, expected:[%d] and got:[%d]",
+				i, et.expectedTokenType, calculatedToken.Type)
+		}
+
+		if et.expectedValue != calculatedToken.Value {
+			t.Fatalf("tests index %d -> token value is wrong, expected:[%q] and got:[%q]",
+				i, et.expectedValue, calculatedToken.Value)
+		}
+	}
+}
+
+func TestNullishTokens(t *testing.T) {
+	myLexer := InitLexer(Mock6)
+
+	for i, et := range NextData6 {
+		calculatedToken := myLexer.NextToken()
+
+		if et.expectedTokenType != calculatedToken.Type {
+			t.Fatalf("tests index %d -> tokenType wrong, expected:[%d] and got:[%d]",
+				i, et.expectedTokenType, calculatedToken.Type)
+		}
+
+		if et.expectedValue != calculatedToken.Value {
+			t.Fatalf("tests index %d -> token value is wrong, expected:[%q] and got:[%q]",
+				i, et.expectedValue, calculatedToken.Value)
+		}
+	}
+}
+
+func TestTokenPositions(t *testing.T) {
+	myLexer := InitFileLexer("main.jipl", Mock7)
+
+	for i, expected := range PositionsData7 {
+		calculatedToken := myLexer.NextToken()
+
+		if calculatedToken.Pos != expected {
+			t.Fatalf("tests index %d -> position of %q is wrong, expected:[%+v] and got:[%+v]",
+				i, calculatedToken.Value, expected, calculatedToken.Pos)
+		}
+	}
+
+	if pos := (token.Position{File: "main.jipl", Line: 2, Column: 3}); pos.String() != "main.jipl:2:3" {
+		t.Fatalf("wrong position string got %s", pos.String())
+	}
+}
+
 // Test data
 var (
 	NextTestData = []struct {
@@ -321,4 +410,109 @@ var (
 	}`
 
 	Mock0 = "=+(){},;"
+
+	Mock4 = "3.14 1e-9 2.5E3 7e 42.length 10"
+
+	NextData4 = []struct {
+		expectedTokenType token.TokenType
+		expectedValue     string
+	}{
+		{expectedTokenType: token.FLOAT, expectedValue: "3.14"},
+		{expectedTokenType: token.FLOAT, expectedValue: "1e-9"},
+		{expectedTokenType: token.FLOAT, expectedValue: "2.5E3"},
+		{expectedTokenType: token.INT, expectedValue: "7"},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "e"},
+		{expectedTokenType: token.INT, expectedValue: "42"},
+		{expectedTokenType: token.DOT, expectedValue: "."},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "length"},
+		{expectedTokenType: token.INT, expectedValue: "10"},
+	}
+
+	Mock5 = "(a, ...rest) => f(...rest) == a.b"
+
+	NextData5 = []struct {
+		expectedTokenType token.TokenType
+		expectedValue     string
+	}{
+		{expectedTokenType: token.LP, expectedValue: "("},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "a"},
+		{expectedTokenType: token.COMMA, expectedValue: ","},
+		{expectedTokenType: token.ELLIPSIS, expectedValue: "..."},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "rest"},
+		{expectedTokenType: token.RP, expectedValue: ")"},
+		{expectedTokenType: token.ARROW, expectedValue: "=>"},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "f"},
+		{expectedTokenType: token.LP, expectedValue: "("},
+		{expectedTokenType: token.ELLIPSIS, expectedValue: "..."},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "rest"},
+		{expectedTokenType: token.RP, expectedValue: ")"},
+		{expectedTokenType: token.EQUAL, expectedValue: "=="},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "a"},
+		{expectedTokenType: token.DOT, expectedValue: "."},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "b"},
+	}
+
+	Mock3 = "a += 1; a -= 2; a *= 3; a /= 4; a %= 5; p.x;"
+
+	NextData3 = []struct {
+		expectedTokenType token.TokenType
+		expectedValue     string
+	}{
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "a"},
+		{expectedTokenType: token.PLUS_ASSIGN, expectedValue: "+="},
+		{expectedTokenType: token.INT, expectedValue: "1"},
+		{expectedTokenType: token.S_COLON, expectedValue: ";"},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "a"},
+		{expectedTokenType: token.MINUS_ASSIGN, expectedValue: "-="},
+		{expectedTokenType: token.INT, expectedValue: "2"},
+		{expectedTokenType: token.S_COLON, expectedValue: ";"},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "a"},
+		{expectedTokenType: token.STAR_ASSIGN, expectedValue: "*="},
+		{expectedTokenType: token.INT, expectedValue: "3"},
+		{expectedTokenType: token.S_COLON, expectedValue: ";"},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "a"},
+		{expectedTokenType: token.SLASH_ASSIGN, expectedValue: "/="},
+		{expectedTokenType: token.INT, expectedValue: "4"},
+		{expectedTokenType: token.S_COLON, expectedValue: ";"},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "a"},
+		{expectedTokenType: token.MODULO_ASSIGN, expectedValue: "%="},
+		{expectedTokenType: token.INT, expectedValue: "5"},
+		{expectedTokenType: token.S_COLON, expectedValue: ";"},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "p"},
+		{expectedTokenType: token.DOT, expectedValue: "."},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "x"},
+		{expectedTokenType: token.S_COLON, expectedValue: ";"},
+	}
+
+	Mock6 = "user?.name ?? null; a ? b"
+
+	NextData6 = []struct {
+		expectedTokenType token.TokenType
+		expectedValue     string
+	}{
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "user"},
+		{expectedTokenType: token.OPT_DOT, expectedValue: "?."},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "name"},
+		{expectedTokenType: token.NULLISH, expectedValue: "??"},
+		{expectedTokenType: token.NULL, expectedValue: "null"},
+		{expectedTokenType: token.S_COLON, expectedValue: ";"},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "a"},
+		{expectedTokenType: token.ILLEGAL, expectedValue: "?"},
+		{expectedTokenType: token.IDENTIFIER, expectedValue: "b"},
+	}
+
+	Mock7 = "def a = 10;\n  a == \"é\" ;\n"
+
+	PositionsData7 = []token.Position{
+		{File: "main.jipl", Line: 1, Column: 1, Offset: 0},
+		{File: "main.jipl", Line: 1, Column: 5, Offset: 4},
+		{File: "main.jipl", Line: 1, Column: 7, Offset: 6},
+		{File: "main.jipl", Line: 1, Column: 9, Offset: 8},
+		{File: "main.jipl", Line: 1, Column: 11, Offset: 10},
+		{File: "main.jipl", Line: 2, Column: 3, Offset: 14},
+		{File: "main.jipl", Line: 2, Column: 5, Offset: 16},
+		{File: "main.jipl", Line: 2, Column: 8, Offset: 19},
+		{File: "main.jipl", Line: 2, Column: 12, Offset: 24},
+		{File: "main.jipl", Line: 3, Column: 1, Offset: 26},
+	}
 )
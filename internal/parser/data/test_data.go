@@ -23,7 +23,9 @@ var (
 	ExpectedReturnValue = []int{545, 101232, 0}
 	Identifier          = `varName;`
 
-	IntegerLit = "81;"
+	IntegerLit    = "81;"
+	BigIntegerLit = "123456789012345678901234567890;"
+	FloatLit      = "25e-2;"
 
 	PrefixExpression = []struct {
 		Input      string
@@ -100,6 +102,19 @@ var (
 		{"1+pow(2*5)/4;", "(1+(pow((2*5))/4))"},
 		{"777++;", "(777++)"},
 		{"max(1,65,2*11,100/2,max(100,12*30))", "max(1,65,(2*11),(100/2),max(100,(12*30)))"},
+		{"-str.length;", "(-str.length)"},
+		{"a.b(1) + c.d * 2;", "(a.b(1)+(c.d*2))"},
+		{"arr.first.second[0];", "arr.first.second[0]"},
+		{"a == b && c == d;", "((a==b)&&(c==d))"},
+		{"a || b && c;", "(a||(b&&c))"},
+		{"a && b || c && d;", "((a&&b)||(c&&d))"},
+		{"x != 0 && 10 / x > 1;", "((x!=0)&&((10/x)>1))"},
+		{"!a || b < c;", "((!a)||(b<c))"},
+		{"a ?? b || c;", "(a??(b||c))"},
+		{"a.b ?? null;", "(a.b??null)"},
+		{"a ?? b ?? c;", "((a??b)??c)"},
+		{"a?.b.c ?? d;", "(a?.b.c??d)"},
+		{"a?.b(1)?.c;", "a?.b(1)?.c"},
 	}
 
 	IfExpression = "if(m>=n) {m+1;} else{n+1;}"
@@ -135,4 +150,195 @@ var (
 			out("hello world");
 		}
 	}`
+	ClassConstructorParams = []struct {
+		Input    string
+		Expected string
+	}{
+		{"class P { constructor(x, y = 2, ...rest) { } }", "class P{constructor(x,y=2,...rest){}}"},
+		{"class P { constructor([x, y], z = x) { } }", "class P{constructor([x,y],z=x){}}"},
+	}
+
+	LoopControl = `for(def i=0;i<=10;i++){
+		if (i == 2) {
+			continue;
+		}
+		break;
+	}`
+
+	LoopControlOutsideLoop = []string{
+		"break;",
+		"if (true) { continue; }",
+		"for(def i=0;i<=10;i++){ function inner() { break; } }",
+	}
+
+	ForLoopOptionalClauses = "for(;;){ break; }"
+	ForLoopGeneral         = "for(i = 0; i < n; i += 2){ }"
+	ForLoopDeclarations    = []struct {
+		Input    string
+		Expected string
+	}{
+		{"for (const n = 3; i < n; i++) { }", "for (const n = 3;; (i<n); (i++) ){}"},
+		{"for (def [i, j] = [0, 9]; i < j; i++) { }", "for (def [i,j] = [0,9];; (i<j); (i++) ){}"},
+	}
+
+	WhileLoop   = "while (i < 10) { i++; }"
+	DoWhileLoop = "do { i++; } while (i < 10);"
+	ForInLoop   = "for (def item in items) { out(item); }"
+
+	MapLit       = `def m = {"one": 1, "two": 1 + 1, 3: true};`
+	EmptyMapLit  = "def m = {};"
+	IndexAssign  = `m["key"] = 10;`
+	BareBlockStm = "{ def a = 1; a; }"
+
+	AnonymousFunctions = []struct {
+		Input    string
+		Expected string
+	}{
+		{"def add = function(a, b) { return a + b; };", "def add = function (a,b){return (a+b);};"},
+		{"(a, b) => a + b;", "(a,b) => {return (a+b);}"},
+		{"() => { out(1); };", "() => {out(1)}"},
+		{"x => x * 2;", "(x) => {return (x*2);}"},
+		{"apply((x) => x + 1, 2);", "apply((x) => {return (x+1);},2)"},
+		{"(a + b) * c;", "((a+b)*c)"},
+	}
+
+	FunctionParams = []struct {
+		Input    string
+		Expected string
+	}{
+		{"function f(a, b = 2) { }", "function f(a,b=2){}"},
+		{"function f(first, ...rest) { }", "function f(first,...rest){}"},
+		{"function f(a = 1, ...rest) { }", "function f(a=1,...rest){}"},
+		{"(a, b = a * 2) => a + b;", "(a,b=(a*2)) => {return (a+b);}"},
+		{"f(...arr, 1);", "f(...arr,1)"},
+		{"[0, ...arr];", "[0,...arr]"},
+		{`connect("x", port = 80);`, "connect(x,port=80)"},
+	}
+
+	FunctionParamsErrors = []struct {
+		Input    string
+		Expected string
+	}{
+		{"function f(a = 1, b) { }", "the required parameter b can't follow a parameter with a default value"},
+		{"function f(...rest, a) { }", "the rest parameter should be the last parameter"},
+		{"function f(a + b) { }", "invalid function parameter: (a+b)"},
+		{"connect(host = 1, 2);", "positional arguments can't follow named arguments"},
+	}
+
+	ElseIfExp = "if (x < 0) { 1 } else if (x == 0) { 2 } else { 3 }"
+
+	MatchExpressions = []struct {
+		Input    string
+		Expected string
+	}{
+		{`match (x) { case 1, 2 => "small"; case "x" => { out(x); } default => "other" }`,
+			`match(x){case 1,2 => {small}case x => {out(x)}default => {other}}`},
+		{"match (v) { case INTEGER => 1; case [a, _, ...rest] => rest; case n => n }",
+			"match(v){case INTEGER => {1}case [a,_,...rest] => {rest}case n => {n}}"},
+		{"match (v) { case -1 => true }", "match(v){case (-1) => {true}}"},
+	}
+
+	MatchErrors = []struct {
+		Input    string
+		Expected string
+	}{
+		{"match (v) { case a + 1 => 1 }", "invalid pattern: (a+1)"},
+		{"match (v) { case [...rest, a] => 1 }", "invalid rest pattern: ...rest, it should be a name at the end of the array"},
+		{"match (v) { default => 1; default => 2 }", "a match expression can't have more than one default arm"},
+		{"match (v) { 1 => 2 }", "expected case or default in the match expression instead got 1"},
+		{"match (1) { case [&&] => 1 }", "expected an expression instead got \"&&\""},
+		{"match (1) { case [1, [2, &&]] => 1 }", "expected an expression instead got \"&&\""},
+	}
+
+	Destructuring = []struct {
+		Input    string
+		Expected string
+	}{
+		{"def [a, b, ...rest] = arr;", "def [a,b,...rest] = arr;"},
+		{"def [x, [y, _]] = [1, [2, 3]];", "def [x,[y,_]] = [1,[2,3]];"},
+		{"function f([a, b], c) { }", "function f([a,b],c){}"},
+		{"([a, b]) => a + b;", "([a,b]) => {return (a+b);}"},
+	}
+
+	DestructuringErrors = []struct {
+		Input    string
+		Expected string
+	}{
+		{"def [a + 1] = arr;", "invalid pattern: (a+1)"},
+		{"def [...rest, a] = arr;", "invalid rest pattern: ...rest, it should be a name at the end of the array"},
+		{"class A { def [a] = [1]; }", "class fields can't be destructured"},
+		{"def [&&] = x;", "expected an expression instead got \"&&\""},
+		{"const [ : ] = 1;", "expected an expression instead got \":\""},
+		{"def [ return ];", "expected an expression instead got \"return\""},
+		{"function f(a, [b, &&]) { }", "expected an expression instead got \"&&\""},
+	}
+
+	ConstStatements = []struct {
+		Input    string
+		Expected string
+	}{
+		{"const x = 5;", "const x = 5;"},
+		{"const [a, b] = arr;", "const [a,b] = arr;"},
+		{"const x = 1; function f(x) { x = 2; }", "const x = 1;function f(x){x = 2}"},
+		{"const x = 1; for (def x in arr) { x++; }", "const x = 1;for (def x in arr){(x++)}"},
+		{"const port = 80; connect(port = port);", "const port = 80;connect(port=port)"},
+		{"const x = 1; if (c) { def x = 2; x = 3; }", "const x = 1;ifc {def x = 2;x = 3}"},
+		{"if (c) { const x = 1; } def x = 2;", "ifc {const x = 1;}def x = 2;"},
+		{"const limit = 10; function take(arr, limit = 3) { }", "const limit = 10;function take(arr,limit=3){}"},
+		{"const b = 1; def f = (a, b = 2) => a + b;", "const b = 1;def f = (a,b=2) => {return (a+b);};"},
+	}
+
+	ConstErrors = []struct {
+		Input    string
+		Expected string
+	}{
+		{"const x = 1; x = 2;", "cannot assign to constant x"},
+		{"const x = 1; x += 2;", "cannot assign to constant x"},
+		{"const x = 1; x++;", "cannot assign to constant x"},
+		{"const x = 1; def x = 2;", "cannot redefine constant x"},
+		{"const x = 1; const x = 2;", "cannot redefine constant x"},
+		{"const [a, b] = [1, 2]; b = 3;", "cannot assign to constant b"},
+		{"const f = 1; function f() { }", "cannot redefine constant f"},
+		{"const x = 1; function g() { x = 2; }", "cannot assign to constant x"},
+		{"{ const x = 1; { x = 2; } }", "cannot assign to constant x"},
+		{"const x = 1; function f(a = (x = 2)) { }", "cannot assign to constant x"},
+		{"const x = 1; def f = (a = x = 2) => a;", "cannot assign to constant x"},
+	}
+
+	SyntaxErrors = []struct {
+		Input    string
+		Expected string
+	}{
+		{"def = 1;", "expected identifier instead got \"=\""},
+		{"def x 1;", "expected \"=\" instead got integer 1"},
+		{"f(1, );", "expected an expression instead got \")\""},
+		{"if (x { }", "expected \")\" instead got \"{\""},
+		{"out(1", "expected \")\" instead got end of input"},
+		{"def x = ?;", "expected an expression instead got illegal character \"?\""},
+	}
+
+	// the inputs with independent syntax errors, every error is reported once
+	// and the statements without errors are kept
+	RecoveryData = []struct {
+		Input      string
+		Errors     []string
+		Statements string
+	}{
+		{"def a = (1 + ;\ndef b = 2;", []string{"1:14: expected an expression instead got \";\""}, "def b = 2;"},
+		{"def = 1; def x 2; def y = 3;", []string{"1:5: expected identifier instead got \"=\"",
+			"1:16: expected \"=\" instead got integer 2"}, "def y = 3;"},
+		{"if (a { out(1); }\nout(2);", []string{"1:7: expected \")\" instead got \"{\""}, "out(2)"},
+		{"if (a { out(1); } else { out(2); }\nout(3);", []string{"1:7: expected \")\" instead got \"{\""}, "out(3)"},
+		{"function f() { def x = ; out(1); }\nf();", []string{"1:24: expected an expression instead got \";\""},
+			"function f(){out(1)}f()"},
+		{"{ def x = }\ndef y = 1;", []string{"1:11: expected an expression instead got \"}\""}, "{}def y = 1;"},
+		{"def m = {\"a\": };\n}\nout(3);", []string{"1:15: expected an expression instead got \"}\"",
+			"2:1: expected an expression instead got \"}\""}, "out(3)"},
+		{"out(1, ;\nout(2;\nout(3);", []string{"1:8: expected an expression instead got \";\"",
+			"2:6: expected \")\" instead got \";\""}, "out(3)"},
+		{"def a = [1, &&, 2];\ndef b = 2;", []string{"1:13: expected an expression instead got \"&&\""}, "def b = 2;"},
+		{"out([&&]);\nout(2);", []string{"1:6: expected an expression instead got \"&&\""}, "out(2)"},
+		{"def f = ([a, b]) => { def x = ; a; };\nf;", []string{"1:31: expected an expression instead got \";\""},
+			"def f = ([a,b]) => {a};f"},
+	}
 )
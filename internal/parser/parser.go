@@ -1,10 +1,13 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
 
 	ast "github.com/houcine7/JIPL/internal/AST"
+	"github.com/houcine7/JIPL/internal/debug"
 	"github.com/houcine7/JIPL/internal/lexer"
 	"github.com/houcine7/JIPL/internal/token"
 )
@@ -16,6 +19,11 @@ type Parser struct {
 	currToken   token.Token // the current token in examination
 	peekedToken token.Token // the next token to parse
 
+	loopDepth int     // number of enclosing loops of the current token
+	inPattern bool    // the patterns of a match arm are being parsed
+	scopes    []scope // the names defined in the enclosing scopes
+	panicking bool    // a syntax error broke the current statement
+
 	prefixParseFuncs map[token.TokenType]prefixParse // function used for prefix parsing
 	infixParseFuncs  map[token.TokenType]infixParse  // function used for infix parsing
 }
@@ -23,6 +31,19 @@ type Parser struct {
 type Error struct {
 	Message string
 	Token   token.Token
+	Code    string // one of the debug error codes, empty for the generic syntax errors
+}
+
+// the position of the token where the error was found
+func (err *Error) Pos() token.Position {
+	return err.Token.Pos
+}
+
+func (err *Error) Error() string {
+	if !err.Token.Pos.IsValid() {
+		return err.Message
+	}
+	return fmt.Sprintf("%s: %s", err.Token.Pos, err.Message)
 }
 
 /*
@@ -38,6 +59,7 @@ func InitParser(l *lexer.Lexer) *Parser {
 	p := &Parser{
 		lexer:  l,
 		errors: make([]*Error, 0),
+		scopes: []scope{{}},
 	}
 
 	p.Next() // to peek the first token
@@ -48,10 +70,12 @@ func InitParser(l *lexer.Lexer) *Parser {
 	// registering prefix parsing functions
 	p.addPrefixFn(token.IDENTIFIER, p.parseIdentifier)
 	p.addPrefixFn(token.INT, p.parseInt)
+	p.addPrefixFn(token.FLOAT, p.parseFloat)
 	p.addAllPrefixFn([]token.TokenType{
 		token.TRUE,
 		token.FALSE,
 	}, p.parseBoolean)
+	p.addPrefixFn(token.NULL, p.parseNull)
 	p.addPrefixFn(token.LP, p.parseGroupExpression)
 	p.addAllPrefixFn([]token.TokenType{
 		token.EX_MARK,
@@ -59,10 +83,15 @@ func InitParser(l *lexer.Lexer) *Parser {
 	}, p.parsePrefixExpression)
 	p.addPrefixFn(token.IF, p.parseIfExpression)
 	p.addPrefixFn(token.FUNCTION, p.parseFunctionExpression)
+	p.addPrefixFn(token.MATCH, p.parseMatchExpression)
+	p.addPrefixFn(token.ELLIPSIS, p.parseSpreadExpression)
 	p.addPrefixFn(token.CLASS, p.parseClass)
 	p.addPrefixFn(token.FOR, p.parseForLoopExpression)
+	p.addPrefixFn(token.WHILE, p.parseWhileLoopExpression)
+	p.addPrefixFn(token.DO, p.parseDoWhileLoopExpression)
 	p.addPrefixFn(token.STRING, p.parseStringLit)
 	p.addPrefixFn(token.LB, p.parseArrayLit)
+	p.addPrefixFn(token.LCB, p.parseMapLit)
 
 	// infix expression parser functions
 	p.infixParseFuncs = make(map[token.TokenType]infixParse)
@@ -82,6 +111,7 @@ func InitParser(l *lexer.Lexer) *Parser {
 		token.GT_OR_EQ,
 		token.AND,
 		token.OR,
+		token.NULLISH,
 	}
 	p.addALlInfixFn(infixParseTokens, p.parseInfixExpression)
 	p.addInfixFn(token.LP, p.parseFunctionCallExp)
@@ -90,6 +120,8 @@ func InitParser(l *lexer.Lexer) *Parser {
 		token.INCREMENT,
 	}, p.parsePostFixExpression)
 	p.addInfixFn(token.LB, p.parseIndexExp)
+	p.addInfixFn(token.DOT, p.parseMemberExp)
+	p.addInfixFn(token.OPT_DOT, p.parseMemberExp)
 
 	return p
 }
@@ -116,23 +148,104 @@ func (p *Parser) Next() {
 
 func (p *Parser) Parse() *ast.Program {
 	program := &ast.Program{}
-	program.Statements = []ast.Statement{}
+	program.Statements = p.parseStatements(token.FILE_ENDED)
+	return program
+}
+
+/*
+* Parses the statements up to the end token, the statements broken by a syntax error
+* are left out so the program never contains nil nodes. after a syntax error the tokens are skipped
+* up to the next synchronization point and the parsing resumes, this way the independent
+* errors are all reported in one pass
+ */
+func (p *Parser) parseStatements(end token.TokenType) []ast.Statement {
+	stms := []ast.Statement{}
+	// the errors recovered inside the block don't break the statement enclosing it
+	outerPanicking := p.panicking
+	defer func() { p.panicking = outerPanicking }()
 
-	for !p.currentTokenEquals(token.FILE_ENDED) {
+	for !p.currentTokenEquals(end) && !p.currentTokenEquals(token.FILE_ENDED) &&
+		len(p.errors) <= MAX_ERRORS {
+		p.panicking = false
 		stm := p.parseStmt()
-		program.Statements = append(program.Statements, stm)
+
+		if p.panicking {
+			p.panicking = false
+			if p.synchronize() && end == token.RCB {
+				continue // the } closing the block ended the statement
+			}
+		} else {
+			stms = append(stms, stm)
+		}
 		// Advance with token
 		p.Next()
 	}
-	return program
+	return stms
+}
+
+// the keywords starting a statement, the parsing resumes at them after a syntax error
+var syncKeywords = map[token.TokenType]bool{
+	token.DEF:      true,
+	token.CONST:    true,
+	token.RETURN:   true,
+	token.BREAK:    true,
+	token.CONTINUE: true,
+	token.IF:       true,
+	token.FOR:      true,
+	token.WHILE:    true,
+	token.DO:       true,
+	token.MATCH:    true,
+	token.CLASS:    true,
+}
+
+/*
+* Skips the tokens of a broken statement: it stops on a ; or before a }, a statement
+* keyword or the end of the input. the blocks opened by the skipped tokens are skipped
+* entirely and end the statement. reports whether it stopped on a } closing the enclosing block
+ */
+func (p *Parser) synchronize() bool {
+	depth := 0
+	for {
+		switch p.currToken.Type {
+		case token.FILE_ENDED:
+			return false
+		case token.LCB:
+			depth++
+		case token.RCB:
+			if depth == 0 {
+				return true
+			}
+			depth--
+			// the skipped block ended the broken construct unless an else follows it
+			if depth == 0 && !p.peekTokenEquals(token.ELSE) {
+				return false
+			}
+		case token.S_COLON:
+			if depth == 0 {
+				return false
+			}
+		}
+		if depth == 0 && (p.peekTokenEquals(token.RCB) || p.peekTokenEquals(token.FILE_ENDED) ||
+			syncKeywords[p.peekedToken.Type]) {
+			return false
+		}
+		p.Next()
+	}
 }
 
 func (p *Parser) parseStmt() ast.Statement {
 	switch p.currToken.Type {
-	case token.DEF:
+	case token.DEF, token.CONST:
 		return p.parseDefStmt()
 	case token.RETURN:
 		return p.parseReturnStmt()
+	case token.BREAK:
+		return p.parseBreakStmt()
+	case token.CONTINUE:
+		return p.parseContinueStmt()
+	case token.LCB:
+		// a { starting a statement is a block, map literals are expressions only
+		return p.parseBlocStatements()
 	// left are expression statement
 	default:
 		return p.parseExpressionStatement()
@@ -153,6 +266,11 @@ func (p *Parser) parseClass() ast.Expression {
 		Token: p.currToken,
 		Value: p.currToken.Value,
 	}
+	p.declare(exp.ClassName.Value, false, exp.ClassName.Token)
+
+	// the fields and methods aren't defined in the enclosing scope
+	p.pushScope()
+	defer p.popScope()
 
 	if !p.expectedNextToken(token.CreateToken(token.LCB, "{")) {
 		return nil
@@ -164,6 +282,13 @@ func (p *Parser) parseClass() ast.Expression {
 	for p.peekTokenEquals(token.DEF) {
 		p.Next()
 		st := p.parseDefStmt()
+		if st == nil {
+			return nil
+		}
+		if st.Pattern != nil {
+			p.syntaxError(&Error{Message: "class fields can't be destructured", Token: st.Token})
+			return nil
+		}
 		fields = append(fields, st)
 	}
 
@@ -174,8 +299,15 @@ func (p *Parser) parseClass() ast.Expression {
 
 	for p.peekTokenEquals(token.FUNCTION) {
 		p.Next()
-		m := p.parseFunctionExpression()
-		methods = append(methods, m.(*ast.FunctionExp))
+		m, ok := p.parseFunctionExpression().(*ast.FunctionExp)
+		if !ok {
+			return nil
+		}
+		if m.Name == nil {
+			p.syntaxError(&Error{Message: "class methods should have a name", Token: m.Token})
+			return nil
+		}
+		methods = append(methods, m)
 	}
 
 	exp.Methods, exp.DataMembers = methods, fields
@@ -196,13 +328,15 @@ func (p *Parser) parseConstructor(className *ast.Identifier) *ast.FunctionExp {
 		return nil
 	}
 
-	exp.Parameters = p.parsePramas() // this parses til the curr token is (
+	if !p.parsePramas(exp) { // this parses til the curr token is )
+		return nil
+	}
 
 	if !p.expectedNextToken(token.CreateToken(token.LCB, "{")) {
 		return nil
 	}
 
-	exp.FnBody = p.parseBlocStatements()
+	exp.FnBody = p.parseFunctionBody(exp)
 
 	return exp
 }
@@ -213,13 +347,22 @@ func (p *Parser) parseConstructor(className *ast.Identifier) *ast.FunctionExp {
 func (p *Parser) parseDefStmt() *ast.DefStatement {
 	stm := &ast.DefStatement{Token: p.currToken}
 
-	// syntax error's
-	if !p.expectedNextToken(token.CreateToken(token.IDENTIFIER, "IDENT")) {
-		return nil
-	}
-	stm.Name = &ast.Identifier{
-		Token: p.currToken,
-		Value: p.currToken.Value,
+	if p.peekTokenEquals(token.LB) {
+		// destructuring def [a, b, ...rest] = arr;
+		p.Next()
+		stm.Pattern = p.parseArrayPattern()
+		if stm.Pattern == nil {
+			return nil
+		}
+	} else {
+		// syntax error's
+		if !p.expectedNextToken(token.CreateToken(token.IDENTIFIER, "IDENT")) {
+			return nil
+		}
+		stm.Name = &ast.Identifier{
+			Token: p.currToken,
+			Value: p.currToken.Value,
+		}
 	}
 	if !p.expectedNextToken(token.CreateToken(token.ASSIGN, "=")) {
 		return nil
@@ -229,6 +372,12 @@ func (p *Parser) parseDefStmt() *ast.DefStatement {
 
 	stm.Value = p.parseExpression(LOWEST)
 
+	if stm.Pattern != nil {
+		p.declarePattern(stm.Pattern, stm.IsConst())
+	} else {
+		p.declare(stm.Name.Value, stm.IsConst(), stm.Name.Token)
+	}
+
 	if p.peekTokenEquals(token.S_COLON) {
 		p.Next()
 	}
@@ -236,13 +385,10 @@ func (p *Parser) parseDefStmt() *ast.DefStatement {
 	return stm
 }
 
-func (p *Parser) parseDefStmtInForLoop() *ast.DefStatement {
-	stm := &ast.DefStatement{Token: p.currToken}
+// parses the def statement of a for loop init, the current token is the identifier
+func (p *Parser) parseDefStmtInForLoop(defToken token.Token) *ast.DefStatement {
+	stm := &ast.DefStatement{Token: defToken}
 
-	// syntax error's
-	if !p.expectedNextToken(token.CreateToken(token.IDENTIFIER, "IDENT")) {
-		return nil
-	}
 	stm.Name = &ast.Identifier{
 		Token: p.currToken,
 		Value: p.currToken.Value,
@@ -254,6 +400,7 @@ func (p *Parser) parseDefStmtInForLoop() *ast.DefStatement {
 	p.Next()
 
 	stm.Value = p.parseExpression(LOWEST)
+	p.declare(stm.Name.Value, false, stm.Name.Token)
 
 	return stm
 }
@@ -263,92 +410,306 @@ func (p *Parser) parseForLoopExpression() ast.Expression {
 
 	exp := &ast.ForLoopExpression{Token: p.currToken}
 
+	// the init statement is in the scope of the loop
+	p.pushScope()
+	defer p.popScope()
+
 	if !p.expectedNextToken(token.CreateToken(token.LP, "(")) {
 		return nil
 	}
-	p.Next() // advance to init parseStmt
-	exp.InitStm = p.parseDefStmtInForLoop()
-
-	if !p.expectedNextToken(token.CreateToken(token.S_COLON, ";")) {
-		return nil
+	p.Next() // advance to init statement
+	if p.currentTokenEquals(token.DEF) && p.peekTokenEquals(token.IDENTIFIER) {
+		defToken := p.currToken
+		p.Next()
+		// for (def x in collection)
+		if p.peekTokenEquals(token.IN) {
+			return p.parseForInLoopExpression(exp.Token)
+		}
+		exp.InitStm = p.parseDefStmtInForLoop(defToken)
+		if !p.expectedNextToken(token.CreateToken(token.S_COLON, ";")) {
+			return nil
+		}
+	} else if p.currentTokenEquals(token.DEF) || p.currentTokenEquals(token.CONST) {
+		// the other declarations: for (const i = 0; ...) or for (def [i, j] = [0, 9]; ...)
+		stm := p.parseDefStmt()
+		if stm == nil {
+			return nil
+		}
+		exp.InitStm = stm
+		// the ; ending the declaration is consumed when it's there
+		if !p.currentTokenEquals(token.S_COLON) && !p.expectedNextToken(token.CreateToken(token.S_COLON, ";")) {
+			return nil
+		}
+	} else if !p.currentTokenEquals(token.S_COLON) {
+		// the init statement could be any expression
+		exp.InitStm = &ast.ExpressionStatement{Token: p.currToken, Expression: p.parseExpression(LOWEST)}
+		if !p.expectedNextToken(token.CreateToken(token.S_COLON, ";")) {
+			return nil
+		}
 	}
+
 	p.Next() // advance to the condition expression
-	exp.Condition = p.parseExpression(LOWEST)
+	if !p.currentTokenEquals(token.S_COLON) {
+		exp.Condition = p.parseExpression(LOWEST)
+		if !p.expectedNextToken(token.CreateToken(token.S_COLON, ";")) {
+			return nil
+		}
+	}
 
-	if !p.expectedNextToken(token.CreateToken(token.S_COLON, ";")) {
+	p.Next() // advance to post iteration
+	if !p.currentTokenEquals(token.RP) {
+		exp.PostIteration = p.parseExpression(LOWEST)
+		if !p.expectedNextToken(token.CreateToken(token.RP, ")")) {
+			return nil
+		}
+	}
+
+	if !p.expectedNextToken(token.CreateToken(token.LCB, "{")) {
 		return nil
 	}
-	p.Next() // advance to post iteration
-	exp.PostIteration = p.parseExpression(LOWEST)
+	exp.Body = p.parseLoopBody()
+
+	return exp
+
+}
+
+// parses for (def x in collection) { ... }, the current token is the loop variable
+func (p *Parser) parseForInLoopExpression(forToken token.Token) ast.Expression {
+	exp := &ast.ForInLoopExpression{
+		Token:    forToken,
+		Variable: &ast.Identifier{Token: p.currToken, Value: p.currToken.Value},
+	}
+
+	p.Next() // the in token
+	p.Next() // advance to the iterable expression
+	exp.Iterable = p.parseExpression(LOWEST)
+	p.declare(exp.Variable.Value, false, exp.Variable.Token)
 
 	if !p.expectedNextToken(token.CreateToken(token.RP, ")")) {
 		return nil
 	}
+	if !p.expectedNextToken(token.CreateToken(token.LCB, "{")) {
+		return nil
+	}
+	exp.Body = p.parseLoopBody()
+
+	return exp
+}
+
+// parses while (condition) { ... }
+func (p *Parser) parseWhileLoopExpression() ast.Expression {
+	exp := &ast.WhileLoopExpression{Token: p.currToken}
+
+	if !p.expectedNextToken(token.CreateToken(token.LP, "(")) {
+		return nil
+	}
+	p.Next()
+	exp.Condition = p.parseExpression(LOWEST)
 
+	if !p.expectedNextToken(token.CreateToken(token.RP, ")")) {
+		return nil
+	}
 	if !p.expectedNextToken(token.CreateToken(token.LCB, "{")) {
 		return nil
 	}
-	exp.Body = p.parseBlocStatements()
+	exp.Body = p.parseLoopBody()
 
 	return exp
+}
+
+// parses do { ... } while (condition)
+func (p *Parser) parseDoWhileLoopExpression() ast.Expression {
+	exp := &ast.DoWhileLoopExpression{Token: p.currToken}
+
+	if !p.expectedNextToken(token.CreateToken(token.LCB, "{")) {
+		return nil
+	}
+	exp.Body = p.parseLoopBody()
+
+	if !p.expectedNextToken(token.CreateToken(token.WHILE, "while")) {
+		return nil
+	}
+	if !p.expectedNextToken(token.CreateToken(token.LP, "(")) {
+		return nil
+	}
+	p.Next()
+	exp.Condition = p.parseExpression(LOWEST)
+
+	if !p.expectedNextToken(token.CreateToken(token.RP, ")")) {
+		return nil
+	}
 
+	return exp
 }
 
 // to parse functionExpression
 func (p *Parser) parseFunctionExpression() ast.Expression {
 	exp := &ast.FunctionExp{Token: p.currToken}
 
-	if !p.expectedNextToken(token.CreateToken(token.IDENTIFIER, "")) {
-		return nil
+	// the name is optional, function(a, b) { ... } is an anonymous function
+	if !p.peekTokenEquals(token.LP) {
+		if !p.expectedNextToken(token.CreateToken(token.IDENTIFIER, "")) {
+			return nil
+		}
+		exp.Name = &ast.Identifier{Token: p.currToken, Value: p.currToken.Value}
+		p.declare(exp.Name.Value, false, exp.Name.Token)
 	}
-	exp.Name = p.parseIdentifier().(*ast.Identifier)
 	if !p.expectedNextToken(token.CreateToken(token.LP, "(")) {
 		return nil
 	}
 
 	// fn params
-	exp.Parameters = p.parsePramas()
+	if !p.parsePramas(exp) {
+		return nil
+	}
 
 	if !p.expectedNextToken(token.CreateToken(token.LCB, "{")) {
 		return nil
 	}
 	// fn body should start with  {
-	exp.FnBody = p.parseBlocStatements()
+	exp.FnBody = p.parseFunctionBody(exp)
 
 	return exp
 }
 
-func (p *Parser) parsePramas() []*ast.Identifier {
-	var params = []*ast.Identifier{}
+// parses the params of a function (a, b = 2, ...rest), the current token is (
+func (p *Parser) parsePramas(fn *ast.FunctionExp) bool {
+	paramExps := p.parseExpressionList(token.CreateToken(token.RP, ")"))
+	if paramExps == nil {
+		return false
+	}
+	return p.setParams(fn, paramExps)
+}
+
+// sets the params of the function from the parsed expressions: an identifier is a required param,
+// an assignment a param with a default value and a spread the rest param
+func (p *Parser) setParams(fn *ast.FunctionExp, paramExps []ast.Expression) bool {
+	fn.Parameters = []*ast.Identifier{}
+	hasDefaults := false
 
-	if p.peekTokenEquals(token.RP) { // in case of no param
-		p.Next()
-		return params
+	for idx, paramExp := range paramExps {
+		if paramExp == nil {
+			return false
+		}
+		if fn.Rest != nil {
+			p.syntaxError(&Error{Message: "the rest parameter should be the last parameter",
+				Token: fn.Token})
+			return false
+		}
+
+		switch param := paramExp.(type) {
+		case *ast.Identifier:
+			if hasDefaults {
+				p.syntaxError(&Error{Message: fmt.Sprintf("the required parameter %s can't follow a parameter with a default value",
+					param.Value), Token: param.Token})
+				return false
+			}
+			fn.Parameters = append(fn.Parameters, param)
+			continue
+		case *ast.AssignmentExpression:
+			if name, ok := param.Left.(*ast.Identifier); ok && param.Operator == "=" {
+				if !hasDefaults {
+					fn.Defaults = make([]ast.Expression, idx, len(paramExps))
+					hasDefaults = true
+				}
+				p.dropAssignmentError(name)
+				fn.Parameters = append(fn.Parameters, name)
+				fn.Defaults = append(fn.Defaults, param.AssignmentValue)
+				continue
+			}
+		case *ast.SpreadExpression:
+			if name, ok := param.Value.(*ast.Identifier); ok {
+				fn.Rest = name
+				continue
+			}
+		case *ast.ArrayLiteral:
+			// a destructured param doesn't have a name so it can't be given by name
+			if hasDefaults {
+				p.syntaxError(&Error{Message: fmt.Sprintf("the required parameter %s can't follow a parameter with a default value",
+					param.ToString()), Token: param.Token})
+				return false
+			}
+			if !p.validPattern(param) {
+				return false
+			}
+			for len(fn.Patterns) < idx {
+				fn.Patterns = append(fn.Patterns, nil)
+			}
+			fn.Patterns = append(fn.Patterns, param)
+			fn.Parameters = append(fn.Parameters, nil)
+			continue
+		}
+
+		p.syntaxError(&Error{Message: fmt.Sprintf("invalid function parameter: %s",
+			paramExp.ToString()), Token: fn.Token})
+		return false
 	}
 
-	p.Next() // advance to params p1,p2....
-	params = append(params, p.parseIdentifier().(*ast.Identifier))
+	return true
+}
 
+func (p *Parser) parseFunctionCallExp(fn ast.Expression) ast.Expression {
+	exp := &ast.FunctionCall{
+		Token:     p.currToken,
+		Function:  fn,
+		Arguments: []ast.Expression{},
+	}
+
+	if p.peekTokenEquals(token.RP) {
+		p.Next()
+		return exp
+	}
+
+	p.Next()
+	exp.Arguments = append(exp.Arguments, p.parseArgument())
 	for p.peekTokenEquals(token.COMMA) {
 		p.Next()
-		p.Next() //advance to next param
-		params = append(params, p.parseIdentifier().(*ast.Identifier))
+		p.Next()
+		exp.Arguments = append(exp.Arguments, p.parseArgument())
 	}
-	//end of params
 	if !p.expectedNextToken(token.CreateToken(token.RP, ")")) {
 		return nil
 	}
 
-	return params
+	// named arguments come after the positional ones
+	hasNamed := false
+	for _, arg := range exp.Arguments {
+		if _, ok := arg.(*ast.NamedArgument); ok {
+			hasNamed = true
+			continue
+		}
+		if hasNamed {
+			p.syntaxError(&Error{Message: "positional arguments can't follow named arguments",
+				Token: exp.Token})
+			return nil
+		}
+	}
+
+	return exp
 }
 
-func (p *Parser) parseFunctionCallExp(fn ast.Expression) ast.Expression {
-	exp := &ast.FunctionCall{
-		Token:    p.currToken,
-		Function: fn,
+// parses an argument of a function call, name = value is a named argument
+func (p *Parser) parseArgument() ast.Expression {
+	if !p.currentTokenEquals(token.IDENTIFIER) || !p.peekTokenEquals(token.ASSIGN) {
+		return p.parseExpression(LOWEST)
 	}
-	exp.Arguments = p.parseExpressionList(token.CreateToken(token.RP, ")"))
 
+	name := &ast.Identifier{Token: p.currToken, Value: p.currToken.Value}
+	p.Next()
+	arg := &ast.NamedArgument{Token: p.currToken, Name: name}
+	p.Next()
+	arg.Value = p.parseExpression(LOWEST)
+	return arg
+}
+
+// the spread of an array ...arr
+func (p *Parser) parseSpreadExpression() ast.Expression {
+	exp := &ast.SpreadExpression{Token: p.currToken}
+	p.Next()
+	exp.Value = p.parseExpression(PREFIX)
+	if exp.Value == nil {
+		return nil
+	}
 	return exp
 }
 
@@ -370,15 +731,75 @@ func (p *Parser) parseReturnStmt() *ast.ReturnStatement {
 	return stm
 }
 
-// group expression
+func (p *Parser) parseBreakStmt() *ast.BreakStatement {
+	stm := &ast.BreakStatement{Token: p.currToken}
+	if p.loopDepth == 0 {
+		p.outsideLoopError(p.currToken)
+	}
+	if p.peekTokenEquals(token.S_COLON) {
+		p.Next()
+	}
+	return stm
+}
+
+func (p *Parser) parseContinueStmt() *ast.ContinueStatement {
+	stm := &ast.ContinueStatement{Token: p.currToken}
+	if p.loopDepth == 0 {
+		p.outsideLoopError(p.currToken)
+	}
+	if p.peekTokenEquals(token.S_COLON) {
+		p.Next()
+	}
+	return stm
+}
+
+// group expression, or the parameters of an arrow function (a, b) => a + b
 func (p *Parser) parseGroupExpression() ast.Expression {
+	lpToken := p.currToken
+	exps := p.parseExpressionList(token.CreateToken(token.RP, ")"))
+	if exps == nil {
+		return nil
+	}
+
+	if p.peekTokenEquals(token.ARROW) && !p.inPattern {
+		return p.parseArrowFunction(exps)
+	}
+
+	if len(exps) != 1 {
+		p.syntaxError(&Error{Message: "a group expression should contain exactly one expression",
+			Token: lpToken})
+		return nil
+	}
+	return exps[0]
+}
+
+// parses the body of an arrow function, the current token is the last token of the params.
+// the body is either a block or a single expression that is returned
+func (p *Parser) parseArrowFunction(paramExps []ast.Expression) ast.Expression {
 	p.Next()
-	grpExp := p.parseExpression(LOWEST)
+	exp := &ast.FunctionExp{Token: p.currToken}
+	if !p.setParams(exp, paramExps) {
+		return nil
+	}
 
-	if !p.expectedNextToken(token.CreateToken(token.RP, ")")) {
+	if p.peekTokenEquals(token.LCB) {
+		p.Next()
+		exp.FnBody = p.parseFunctionBody(exp)
+		return exp
+	}
+
+	p.Next()
+	returnStm := &ast.ReturnStatement{Token: token.CreateToken(token.RETURN, "return")}
+	p.pushScope()
+	p.declareParams(exp)
+	returnStm.ReturnValue = p.parseExpression(LOWEST)
+	p.popScope()
+	if returnStm.ReturnValue == nil {
 		return nil
 	}
-	return grpExp
+	exp.FnBody = &ast.BlockStm{Token: exp.Token, Statements: []ast.Statement{returnStm}}
+
+	return exp
 }
 
 // parser if expressions
@@ -405,6 +826,15 @@ func (p *Parser) parseIfExpression() ast.Expression {
 
 	if p.peekTokenEquals(token.ELSE) {
 		p.Next()
+		if p.peekTokenEquals(token.IF) {
+			p.Next()
+			elseIf, ok := p.parseIfExpression().(*ast.IfExpression)
+			if !ok {
+				return nil
+			}
+			exp.ElseIf = elseIf
+			return exp
+		}
 		if !p.expectedNextToken(token.CreateToken(token.LCB, "{")) {
 			return nil
 		}
@@ -414,22 +844,212 @@ func (p *Parser) parseIfExpression() ast.Expression {
 	return exp
 }
 
-func (p *Parser) parseBlocStatements() *ast.BlockStm {
-	blockStm := &ast.BlockStm{Token: p.currToken}
-	stms := []ast.Statement{}
+// parses match (value) { case pattern, pattern => body; default => body }
+func (p *Parser) parseMatchExpression() ast.Expression {
+	exp := &ast.MatchExpression{Token: p.currToken}
+
+	if !p.expectedNextToken(token.CreateToken(token.LP, "(")) {
+		return nil
+	}
 	p.Next()
+	exp.Value = p.parseExpression(LOWEST)
+	if !p.expectedNextToken(token.CreateToken(token.RP, ")")) {
+		return nil
+	}
+	if !p.expectedNextToken(token.CreateToken(token.LCB, "{")) {
+		return nil
+	}
 
-	for !p.currentTokenEquals(token.RCB) &&
-		!p.currentTokenEquals(token.FILE_ENDED) {
-		stm := p.parseStmt()
-		stms = append(stms, stm)
+	for !p.peekTokenEquals(token.RCB) {
 		p.Next()
+		switch p.currToken.Type {
+		case token.CASE:
+			arm := p.parseMatchArm()
+			if arm == nil {
+				return nil
+			}
+			exp.Arms = append(exp.Arms, arm)
+		case token.DEFAULT:
+			if exp.Default != nil {
+				p.syntaxError(&Error{Message: "a match expression can't have more than one default arm",
+					Token: p.currToken})
+				return nil
+			}
+			if !p.expectedNextToken(token.CreateToken(token.ARROW, "=>")) {
+				return nil
+			}
+			exp.Default = p.parseMatchArmBody()
+			if exp.Default == nil {
+				return nil
+			}
+		default:
+			p.syntaxError(&Error{Message: fmt.Sprintf("expected case or default in the match expression instead got %s",
+				p.currToken.Value), Token: p.currToken})
+			return nil
+		}
+
+		for p.peekTokenEquals(token.S_COLON) {
+			p.Next()
+		}
 	}
-	blockStm.Statements = stms
+	p.Next() // the closing }
+
+	return exp
+}
+
+func (p *Parser) parseMatchArm() *ast.MatchArm {
+	arm := &ast.MatchArm{Token: p.currToken}
+
+	// patterns are not arrow functions, x => ... is the pattern x and its body
+	p.inPattern = true
+	p.Next()
+	arm.Patterns = append(arm.Patterns, p.parsePattern())
+	for p.peekTokenEquals(token.COMMA) {
+		p.Next()
+		p.Next()
+		arm.Patterns = append(arm.Patterns, p.parsePattern())
+	}
+	p.inPattern = false
+
+	for _, pattern := range arm.Patterns {
+		if pattern == nil {
+			return nil
+		}
+	}
+	if !p.expectedNextToken(token.CreateToken(token.ARROW, "=>")) {
+		return nil
+	}
+	p.pushScope()
+	for _, pattern := range arm.Patterns {
+		p.declarePattern(pattern, false)
+	}
+	arm.Body = p.parseMatchArmBody()
+	p.popScope()
+	if arm.Body == nil {
+		return nil
+	}
+	return arm
+}
+
+// the body of an arm is a block or a single expression, the current token is =>
+func (p *Parser) parseMatchArmBody() *ast.BlockStm {
+	if p.peekTokenEquals(token.LCB) {
+		p.Next()
+		return p.parseBlocStatements()
+	}
+
+	p.Next()
+	stm := &ast.ExpressionStatement{Token: p.currToken}
+	stm.Expression = p.parseExpression(LOWEST)
+	if stm.Expression == nil {
+		return nil
+	}
+	return &ast.BlockStm{Token: stm.Token, Statements: []ast.Statement{stm}}
+}
+
+// parses an array pattern, the current token is [
+func (p *Parser) parseArrayPattern() *ast.ArrayLiteral {
+	p.inPattern = true
+	pattern, ok := p.parseArrayLit().(*ast.ArrayLiteral)
+	p.inPattern = false
+	if !ok || !p.validPattern(pattern) {
+		return nil
+	}
+	return pattern
+}
+
+// parses a pattern: a literal, a name, _, a type name or an array of patterns [a, _, ...rest]
+func (p *Parser) parsePattern() ast.Expression {
+	pattern := p.parseExpression(LOWEST)
+	if pattern == nil || !p.validPattern(pattern) {
+		return nil
+	}
+	return pattern
+}
+
+func (p *Parser) validPattern(pattern ast.Expression) bool {
+	// a syntax error inside the pattern is already reported
+	if pattern == nil || p.panicking {
+		return false
+	}
+
+	switch pat := pattern.(type) {
+	case *ast.IntegerLiteral, *ast.FloatLiteral, *ast.StringLiteral, *ast.BooleanExp, *ast.NullLiteral, *ast.Identifier:
+		return true
+	case *ast.PrefixExpression:
+		switch pat.Right.(type) {
+		case *ast.IntegerLiteral, *ast.FloatLiteral:
+			if pat.Operator == "-" {
+				return true
+			}
+		}
+	case *ast.ArrayLiteral:
+		for idx, element := range pat.Values {
+			if spread, ok := element.(*ast.SpreadExpression); ok {
+				if _, isIdent := spread.Value.(*ast.Identifier); isIdent && idx == len(pat.Values)-1 {
+					continue
+				}
+				p.syntaxError(&Error{Message: fmt.Sprintf("invalid rest pattern: %s, it should be a name at the end of the array",
+					element.ToString()), Token: pat.Token})
+				return false
+			}
+			if !p.validPattern(element) {
+				return false
+			}
+		}
+		return true
+	}
+
+	p.syntaxError(&Error{Message: fmt.Sprintf("invalid pattern: %s", pattern.ToString()),
+		Token: p.currToken})
+	return false
+}
+
+func (p *Parser) parseBlocStatements() *ast.BlockStm {
+	blockStm := &ast.BlockStm{Token: p.currToken}
+	p.Next()
+
+	p.pushScope()
+	blockStm.Statements = p.parseStatements(token.RCB)
+	p.popScope()
 	return blockStm
 
 }
 
+// parses the body of a loop, break and continue are allowed inside
+func (p *Parser) parseLoopBody() *ast.BlockStm {
+	p.loopDepth++
+	body := p.parseBlocStatements()
+	p.loopDepth--
+	return body
+}
+
+// parses a function body, a function starts a new loop nesting
+// so break and continue can't jump out of it
+func (p *Parser) parseFunctionBody(fn *ast.FunctionExp) *ast.BlockStm {
+	outerDepth := p.loopDepth
+	p.loopDepth = 0
+	p.pushScope()
+	p.declareParams(fn)
+	body := p.parseBlocStatements()
+	p.popScope()
+	p.loopDepth = outerDepth
+	return body
+}
+
+func (p *Parser) declareParams(fn *ast.FunctionExp) {
+	for idx, param := range fn.Parameters {
+		if param == nil {
+			p.declarePattern(fn.Patterns[idx], false)
+			continue
+		}
+		p.declare(param.Value, false, param.Token)
+	}
+	if fn.Rest != nil {
+		p.declare(fn.Rest.Value, false, fn.Rest.Token)
+	}
+}
+
 // expression statements parsing
 func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 
@@ -474,7 +1094,11 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 func (p *Parser) parseIdentifier() ast.Expression {
 	stm := &ast.Identifier{Token: p.currToken,
 		Value: p.currToken.Value}
-	if p.peekTokenEquals(token.ASSIGN) {
+	if p.peekTokenEquals(token.ARROW) && !p.inPattern {
+		// single parameter arrow function x => x * 2
+		return p.parseArrowFunction([]ast.Expression{stm})
+	}
+	if p.peekAssignment() {
 		p.Next()
 		exp := p.parseAssignmentExpr(stm)
 		return exp
@@ -486,16 +1110,38 @@ func (p *Parser) parseInt() ast.Expression {
 	exp := &ast.IntegerLiteral{Token: p.currToken}
 	val, err := strconv.ParseInt(p.currToken.Value, 0, 0)
 
+	if errors.Is(err, strconv.ErrRange) {
+		// literals larger than an int are arbitrary precision integers
+		if bigVal, ok := new(big.Int).SetString(p.currToken.Value, 0); ok {
+			exp.Big = bigVal
+			return exp
+		}
+	}
+
 	if err != nil {
 		errMsg := fmt.Sprintf("Parsing error, couldn't parse string %s to Integer value",
 			p.currToken.Value)
-		p.errors = append(p.errors, &Error{Message: errMsg, Token: p.currToken})
+		p.syntaxError(&Error{Message: errMsg, Token: p.currToken})
 		return nil
 	}
 	exp.Value = int(val)
 	return exp
 }
 
+func (p *Parser) parseFloat() ast.Expression {
+	exp := &ast.FloatLiteral{Token: p.currToken}
+	val, err := strconv.ParseFloat(p.currToken.Value, 64)
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Parsing error, couldn't parse string %s to Float value",
+			p.currToken.Value)
+		p.syntaxError(&Error{Message: errMsg, Token: p.currToken})
+		return nil
+	}
+	exp.Value = val
+	return exp
+}
+
 func (p *Parser) parseBoolean() ast.Expression {
 	exp := &ast.BooleanExp{
 		Token: p.currToken,
@@ -505,6 +1151,10 @@ func (p *Parser) parseBoolean() ast.Expression {
 	return exp
 }
 
+func (p *Parser) parseNull() ast.Expression {
+	return &ast.NullLiteral{Token: p.currToken}
+}
+
 func (p *Parser) parsePrefixExpression() ast.Expression {
 	exp := &ast.PrefixExpression{
 		Token:    p.currToken,
@@ -536,14 +1186,17 @@ func (p *Parser) parsePostFixExpression(left ast.Expression) ast.Expression {
 		Operator: p.currToken.Value,
 		Left:     left,
 	}
+	p.checkAssignable(left)
 	return exp
 }
 
-func (p *Parser) parseAssignmentExpr(left *ast.Identifier) ast.Expression {
+func (p *Parser) parseAssignmentExpr(left ast.Expression) ast.Expression {
 	exp := &ast.AssignmentExpression{
-		Token: p.currToken,
-		Left:  left,
+		Token:    p.currToken,
+		Operator: p.currToken.Value,
+		Left:     left,
 	}
+	p.checkAssignable(left)
 
 	p.Next()
 	exp.AssignmentValue = p.parseExpression(LOWEST)
@@ -557,6 +1210,9 @@ func (p *Parser) parseArrayLit() ast.Expression {
 	}
 
 	exp.Values = p.parseExpressionList(token.CreateToken(token.RB, "]"))
+	if exp.Values == nil {
+		return nil
+	}
 
 	return exp
 }
@@ -582,11 +1238,46 @@ func (p *Parser) parseExpressionList(t token.Token) []ast.Expression {
 	if !p.expectedNextToken(t) {
 		return nil
 	}
+	// an element broken by a syntax error would leave a nil in the list
+	if p.panicking {
+		return nil
+	}
 
 	return res
 
 }
 
+func (p *Parser) parseMapLit() ast.Expression {
+	exp := &ast.MapLiteral{
+		Token: p.currToken,
+		Pairs: []ast.MapPair{},
+	}
+
+	for !p.peekTokenEquals(token.RCB) {
+		p.Next()
+		key := p.parseExpression(LOWEST)
+
+		if !p.expectedNextToken(token.CreateToken(token.COLON, ":")) {
+			return nil
+		}
+
+		p.Next()
+		value := p.parseExpression(LOWEST)
+		exp.Pairs = append(exp.Pairs, ast.MapPair{Key: key, Value: value})
+
+		if !p.peekTokenEquals(token.RCB) &&
+			!p.expectedNextToken(token.CreateToken(token.COMMA, ",")) {
+			return nil
+		}
+	}
+
+	if !p.expectedNextToken(token.CreateToken(token.RCB, "}")) {
+		return nil
+	}
+
+	return exp
+}
+
 func (p *Parser) parseIndexExp(left ast.Expression) ast.Expression {
 
 	exp := &ast.IndexExpression{
@@ -601,20 +1292,99 @@ func (p *Parser) parseIndexExp(left ast.Expression) ast.Expression {
 		return nil
 	}
 
+	if p.peekAssignment() {
+		p.Next()
+		return p.parseAssignmentExpr(exp)
+	}
+
 	return exp
 
 }
 
+func (p *Parser) parseMemberExp(object ast.Expression) ast.Expression {
+	exp := &ast.MemberExpression{
+		Token:    p.currToken,
+		Object:   object,
+		Optional: p.currentTokenEquals(token.OPT_DOT),
+	}
+
+	if !p.expectedNextToken(token.CreateToken(token.IDENTIFIER, "IDENT")) {
+		return nil
+	}
+	exp.Property = &ast.Identifier{Token: p.currToken, Value: p.currToken.Value}
+
+	if p.peekAssignment() {
+		if exp.Optional {
+			p.syntaxError(&Error{Message: fmt.Sprintf("can't assign to the optional member %s", exp.ToString()),
+				Token: p.peekedToken})
+			return nil
+		}
+		p.Next()
+		return p.parseAssignmentExpr(exp)
+	}
+
+	return exp
+}
+
 // ERRORS
 func (p *Parser) notFoundPrefixFunctionError(t token.Token) {
-	msg := fmt.Sprintf("no prefix function for the given tokenType={%d,%s} found", t.Type, t.Value)
-	p.errors = append(p.errors, &Error{msg, t})
+	msg := fmt.Sprintf("expected an expression instead got %s", describeToken(t))
+	p.syntaxError(&Error{Message: msg, Token: t, Code: debug.MISSING_EXPRESSION})
+}
+
+func (p *Parser) outsideLoopError(t token.Token) {
+	msg := fmt.Sprintf("%s statement can only be used inside a loop", t.Value)
+	p.addError(&Error{Message: msg, Token: t})
+}
+
+// describes a token in the error messages: identifier x, integer 10, ")"
+func describeToken(t token.Token) string {
+	switch t.Type {
+	case token.IDENTIFIER, token.INT, token.FLOAT:
+		return fmt.Sprintf("%s %s", t.Type, t.Value)
+	case token.STRING, token.ILLEGAL:
+		return fmt.Sprintf("%s %q", t.Type, t.Value)
+	case token.FILE_ENDED:
+		return t.Type.String()
+	default:
+		return fmt.Sprintf("%q", t.Type.String())
+	}
 }
 
 func (p *Parser) Errors() []*Error {
 	return p.errors
 }
 
+// the errors reported before the parsing stops
+const MAX_ERRORS = 20
+
+/*
+* Records an error: the errors following a syntax error in the same statement and the
+* errors at the position of a previous one are cascades, they aren't kept.
+* after MAX_ERRORS errors the parsing stops
+ */
+func (p *Parser) addError(err *Error) {
+	if p.panicking || len(p.errors) > MAX_ERRORS {
+		return
+	}
+	for _, e := range p.errors {
+		if e.Token.Pos.IsValid() && e.Token.Pos == err.Token.Pos {
+			return
+		}
+	}
+	if len(p.errors) == MAX_ERRORS {
+		err = &Error{Message: fmt.Sprintf("too many errors, the parsing stopped after %d errors", MAX_ERRORS),
+			Token: err.Token}
+	}
+	p.errors = append(p.errors, err)
+}
+
+// records an error that breaks the current statement, the parser resynchronizes after it
+func (p *Parser) syntaxError(err *Error) {
+	p.addError(err)
+	p.panicking = true
+}
+
 // Helper functions
 func (p *Parser) currentTokenEquals(t token.TokenType) bool {
 	return p.currToken.Type == t
@@ -624,6 +1394,17 @@ func (p *Parser) peekTokenEquals(t token.TokenType) bool {
 	return p.peekedToken.Type == t
 }
 
+// checks if the next token is = or a compound assignment operator (+= -= ...)
+func (p *Parser) peekAssignment() bool {
+	switch p.peekedToken.Type {
+	case token.ASSIGN, token.PLUS_ASSIGN, token.MINUS_ASSIGN,
+		token.STAR_ASSIGN, token.SLASH_ASSIGN, token.MODULO_ASSIGN:
+		return true
+	default:
+		return false
+	}
+}
+
 /*
 * function checks if the given token has the same type of the next token
 *  advances the parser if true if not it adds parsing errors
@@ -658,11 +1439,14 @@ func (p *Parser) currentPrecedence() int {
  */
 func (p *Parser) peekedError(expectedToken token.Token) {
 
-	errorMessage := fmt.Sprintf("wrong next token type expected token is %s instead got %s",
-		expectedToken.Value, p.peekedToken.Value)
+	expected := expectedToken.Type.String()
+	if expectedToken.Type != token.IDENTIFIER {
+		expected = fmt.Sprintf("%q", expected)
+	}
+	errorMessage := fmt.Sprintf("expected %s instead got %s", expected, describeToken(p.peekedToken))
 
 	// append message to the errors array
-	p.errors = append(p.errors, &Error{errorMessage, p.peekedToken})
+	p.syntaxError(&Error{Message: errorMessage, Token: p.peekedToken, Code: debug.UNEXPECTED_TOKEN})
 }
 
 // functions to add entries to the prefixParseFun and  infixParseFun
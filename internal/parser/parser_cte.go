@@ -8,7 +8,10 @@ import (
 const (
 	_ int = iota
 	LOWEST
-	EQUALS //==
+	NULLISH     // ??
+	LOGICAL_OR  // ||
+	LOGICAL_AND // &&
+	EQUALS      //==
 
 	LESS_OR_GREATER // > <
 	SUM             // +
@@ -37,10 +40,15 @@ var precedences = map[token.TokenType]int{
 	token.STAR:   PRODUCT,
 	token.MODULO: PRODUCT,
 
-	token.AND: EQUALS,
-	token.OR:  EQUALS,
+	token.AND: LOGICAL_AND,
+	token.OR:  LOGICAL_OR,
 
-	token.LP: CALL,
+	token.NULLISH: NULLISH,
+
+	token.LP:  CALL,
+	token.DOT: CALL,
+
+	token.OPT_DOT: CALL,
 
 	token.INCREMENT: INCREMENT,
 	token.DECREMENT: INCREMENT,
@@ -2,6 +2,8 @@ package parser
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	ast "github.com/houcine7/JIPL/internal/AST"
@@ -118,6 +120,46 @@ func TestIntegerLiteral(t *testing.T) {
 	}
 }
 
+func TestBigIntegerLiteral(t *testing.T) {
+	pr, parser := getProg(data.BigIntegerLit)
+
+	checkParserErrors(parser, t)
+	checkIsProgramStmLengthValid(pr, t, 1)
+
+	stm, ok := pr.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("program.statement[0] is not of type expressionStatement instead got=%T",
+			pr.Statements[0])
+	}
+
+	intLiteral, ok := stm.Expression.(*ast.IntegerLiteral)
+	if !ok {
+		t.Fatalf("stm.Expression is not of type *ast.IntegerLiteral instead got=%T", stm.Expression)
+	}
+	if intLiteral.Big == nil || intLiteral.Big.String() != "123456789012345678901234567890" {
+		t.Fatalf("intLiteral.Big is not as expected, got=%v", intLiteral.Big)
+	}
+}
+
+func TestFloatLiteral(t *testing.T) {
+	pr, parser := getProg(data.FloatLit)
+
+	checkParserErrors(parser, t)
+	checkIsProgramStmLengthValid(pr, t, 1)
+
+	stm := pr.Statements[0].(*ast.ExpressionStatement)
+	floatLiteral, ok := stm.Expression.(*ast.FloatLiteral)
+	if !ok {
+		t.Fatalf("stm.Expression is not of type *ast.FloatLiteral instead got=%T",
+			stm.Expression)
+	}
+
+	if floatLiteral.Value != 0.25 {
+		t.Errorf("the Float literal value is not correct, expected=%f instead got=%f",
+			0.25, floatLiteral.Value)
+	}
+}
+
 // prefix operators
 func TestParsePrefixExp(t *testing.T) {
 	tests := data.PrefixExpression
@@ -256,6 +298,173 @@ func TestIfExpression(t *testing.T) {
 	}
 }
 
+func TestElseIfExpression(t *testing.T) {
+	pr, parser := getProg(data.ElseIfExp)
+	checkParserErrors(parser, t)
+	checkIsProgramStmLengthValid(pr, t, 1)
+
+	stm := pr.Statements[0].(*ast.ExpressionStatement)
+	ifExp, ok := stm.Expression.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("stm.Expression is not of type *ast.IfExpression instead got=%T", stm.Expression)
+	}
+	if ifExp.ElseIf == nil || ifExp.ElseBody != nil {
+		t.Fatalf("the else if branch should be set on the first if expression")
+	}
+	if !testInfixExpression(t, ifExp.ElseIf.Condition, "x", 0, "==") {
+		return
+	}
+	if ifExp.ElseIf.ElseBody == nil {
+		t.Fatalf("the else branch should be set on the else if expression")
+	}
+}
+
+func TestMatchExpression(t *testing.T) {
+	for _, test := range data.MatchExpressions {
+		prog, parser := getProg(test.Input)
+		checkParserErrors(parser, t)
+		checkIsProgramStmLengthValid(prog, t, 1)
+		ans := prog.ToString()
+		if ans != test.Expected {
+			t.Fatalf("wrong result for %q expected=%s and got=%s", test.Input, test.Expected, ans)
+		}
+	}
+
+	for _, test := range data.MatchErrors {
+		_, parser := getProg(test.Input)
+		errors := parser.Errors()
+		if len(errors) == 0 {
+			t.Fatalf("expected a parsing error for %q", test.Input)
+		}
+		if errors[0].Message != test.Expected {
+			t.Fatalf("wrong error for %q expected=%q and got=%q", test.Input, test.Expected, errors[0].Message)
+		}
+	}
+}
+
+func TestDestructuring(t *testing.T) {
+	for _, test := range data.Destructuring {
+		prog, parser := getProg(test.Input)
+		checkParserErrors(parser, t)
+		checkIsProgramStmLengthValid(prog, t, 1)
+		ans := prog.ToString()
+		if ans != test.Expected {
+			t.Fatalf("wrong result for %q expected=%s and got=%s", test.Input, test.Expected, ans)
+		}
+	}
+
+	for _, test := range data.DestructuringErrors {
+		_, parser := getProg(test.Input)
+		errors := parser.Errors()
+		if len(errors) == 0 {
+			t.Fatalf("expected a parsing error for %q", test.Input)
+		}
+		if errors[0].Message != test.Expected {
+			t.Fatalf("wrong error for %q expected=%q and got=%q", test.Input, test.Expected, errors[0].Message)
+		}
+	}
+}
+
+func TestConstStatements(t *testing.T) {
+	for _, test := range data.ConstStatements {
+		prog, parser := getProg(test.Input)
+		checkParserErrors(parser, t)
+		ans := prog.ToString()
+		if ans != test.Expected {
+			t.Fatalf("wrong result for %q expected=%s and got=%s", test.Input, test.Expected, ans)
+		}
+	}
+
+	for _, test := range data.ConstErrors {
+		_, parser := getProg(test.Input)
+		errors := parser.Errors()
+		if len(errors) == 0 {
+			t.Fatalf("expected a parsing error for %q", test.Input)
+		}
+		if errors[0].Message != test.Expected {
+			t.Fatalf("wrong error for %q expected=%q and got=%q", test.Input, test.Expected, errors[0].Message)
+		}
+	}
+}
+
+func TestOptionalMemberAssignment(t *testing.T) {
+	_, parser := getProg("user?.name = 1;")
+	errors := parser.Errors()
+	if len(errors) == 0 {
+		t.Fatalf("expected a parsing error for the assignment of an optional member")
+	}
+	if errors[0].Message != "can't assign to the optional member user?.name" {
+		t.Fatalf("wrong error got=%q", errors[0].Message)
+	}
+}
+
+func TestSyntaxErrorMessages(t *testing.T) {
+	for _, test := range data.SyntaxErrors {
+		_, parser := getProg(test.Input)
+		errors := parser.Errors()
+		if len(errors) == 0 {
+			t.Fatalf("expected a parsing error for %q", test.Input)
+		}
+		if errors[0].Message != test.Expected {
+			t.Fatalf("wrong error for %q expected=%q and got=%q", test.Input, test.Expected, errors[0].Message)
+		}
+	}
+}
+
+func TestErrorRecovery(t *testing.T) {
+	for _, test := range data.RecoveryData {
+		pr, parser := getProg(test.Input)
+		errors := parser.Errors()
+		if len(errors) != len(test.Errors) {
+			t.Fatalf("wrong number of errors for %q expected %d instead got %d: %v", test.Input, len(test.Errors), len(errors), errors)
+		}
+		for idx, err := range errors {
+			if err.Error() != test.Errors[idx] {
+				t.Fatalf("wrong error for %q expected=%q and got=%q", test.Input, test.Errors[idx], err.Error())
+			}
+		}
+		for _, stm := range pr.Statements {
+			if stm == nil || reflect.ValueOf(stm).IsNil() {
+				t.Fatalf("the program of %q contains a nil statement", test.Input)
+			}
+		}
+		if pr.ToString() != test.Statements {
+			t.Fatalf("wrong statements for %q expected=%q and got=%q", test.Input, test.Statements, pr.ToString())
+		}
+	}
+}
+
+func TestErrorsLimit(t *testing.T) {
+	_, parser := getProg(strings.Repeat("def = 1;\n", MAX_ERRORS+10))
+	errors := parser.Errors()
+	if len(errors) != MAX_ERRORS+1 {
+		t.Fatalf("expected %d errors instead got %d", MAX_ERRORS+1, len(errors))
+	}
+	if errors[MAX_ERRORS].Message != fmt.Sprintf("too many errors, the parsing stopped after %d errors", MAX_ERRORS) {
+		t.Fatalf("wrong last error got %q", errors[MAX_ERRORS].Message)
+	}
+}
+
+func TestErrorPositions(t *testing.T) {
+	_, parser := getProg("def a = 1;\ndef = 2;")
+	errors := parser.Errors()
+	if len(errors) == 0 {
+		t.Fatalf("expected a parsing error")
+	}
+	if errors[0].Pos().Line != 2 || errors[0].Pos().Column != 5 {
+		t.Fatalf("wrong error position expected 2:5 got %s", errors[0].Pos())
+	}
+	if errors[0].Error() != "2:5: "+errors[0].Message {
+		t.Fatalf("the error doesn't start with its position got %q", errors[0].Error())
+	}
+
+	pr, parser := getProg("def a = 1;\n  a + 2;")
+	checkParserErrors(parser, t)
+	if pos := pr.Statements[1].Pos(); pos.Line != 2 || pos.Column != 3 {
+		t.Fatalf("wrong statement position expected 2:3 got %s", pos)
+	}
+}
+
 func TestForLoopFunctions(t *testing.T) {
 	input := data.ForLoopTestSimple
 
@@ -306,6 +515,97 @@ func TestForLoopFunctions(t *testing.T) {
 	}
 }
 
+func TestForLoopGeneralClauses(t *testing.T) {
+	pr, parser := getProg(data.ForLoopOptionalClauses)
+	checkParserErrors(parser, t)
+	checkIsProgramStmLengthValid(pr, t, 1)
+
+	exp := pr.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.ForLoopExpression)
+	if exp.InitStm != nil || exp.Condition != nil || exp.PostIteration != nil {
+		t.Fatalf("the clauses of for(;;) should be nil instead got %s", exp.ToString())
+	}
+
+	pr, parser = getProg(data.ForLoopGeneral)
+	checkParserErrors(parser, t)
+	checkIsProgramStmLengthValid(pr, t, 1)
+
+	exp = pr.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.ForLoopExpression)
+	initStm, ok := exp.InitStm.(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("exp.InitStm is not of type *ast.ExpressionStatement instead got %T", exp.InitStm)
+	}
+	if _, ok := initStm.Expression.(*ast.AssignmentExpression); !ok {
+		t.Fatalf("initStm.Expression is not of type *ast.AssignmentExpression instead got %T", initStm.Expression)
+	}
+	if !testInfixExpression(t, exp.Condition, "i", "n", "<") {
+		return
+	}
+	post, ok := exp.PostIteration.(*ast.AssignmentExpression)
+	if !ok {
+		t.Fatalf("exp.PostIteration is not of type *ast.AssignmentExpression instead got %T", exp.PostIteration)
+	}
+	if post.Operator != "+=" {
+		t.Fatalf("the post iteration operator is not += instead got %s", post.Operator)
+	}
+
+	for _, test := range data.ForLoopDeclarations {
+		pr, parser = getProg(test.Input)
+		checkParserErrors(parser, t)
+		checkIsProgramStmLengthValid(pr, t, 1)
+
+		exp = pr.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.ForLoopExpression)
+		if _, ok := exp.InitStm.(*ast.DefStatement); !ok {
+			t.Fatalf("exp.InitStm of %q is not of type *ast.DefStatement instead got %T", test.Input, exp.InitStm)
+		}
+		if exp.ToString() != test.Expected {
+			t.Fatalf("wrong result for %q expected=%s and got=%s", test.Input, test.Expected, exp.ToString())
+		}
+	}
+}
+
+func TestOtherLoops(t *testing.T) {
+	pr, parser := getProg(data.WhileLoop)
+	checkParserErrors(parser, t)
+	checkIsProgramStmLengthValid(pr, t, 1)
+
+	whileExp, ok := pr.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.WhileLoopExpression)
+	if !ok {
+		t.Fatalf("the expression is not of type *ast.WhileLoopExpression instead got %T",
+			pr.Statements[0].(*ast.ExpressionStatement).Expression)
+	}
+	if !testInfixExpression(t, whileExp.Condition, "i", 10, "<") {
+		return
+	}
+
+	pr, parser = getProg(data.DoWhileLoop)
+	checkParserErrors(parser, t)
+	checkIsProgramStmLengthValid(pr, t, 1)
+
+	doWhileExp, ok := pr.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.DoWhileLoopExpression)
+	if !ok {
+		t.Fatalf("the expression is not of type *ast.DoWhileLoopExpression instead got %T",
+			pr.Statements[0].(*ast.ExpressionStatement).Expression)
+	}
+	if len(doWhileExp.Body.Statements) != 1 {
+		t.Fatalf("the do while body should have 1 statement instead got %d", len(doWhileExp.Body.Statements))
+	}
+	if !testInfixExpression(t, doWhileExp.Condition, "i", 10, "<") {
+		return
+	}
+
+	pr, parser = getProg(data.ForInLoop)
+	checkParserErrors(parser, t)
+	checkIsProgramStmLengthValid(pr, t, 1)
+
+	forInExp, ok := pr.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.ForInLoopExpression)
+	if !ok {
+		t.Fatalf("the expression is not of type *ast.ForInLoopExpression instead got %T",
+			pr.Statements[0].(*ast.ExpressionStatement).Expression)
+	}
+	testIdentifier(t, forInExp.Variable, "item")
+	testIdentifier(t, forInExp.Iterable, "items")
+}
+
 func TestParseFunctions(t *testing.T) {
 	input := data.FunctionExp2
 	pr, parser := getProg(input)
@@ -331,6 +631,41 @@ func TestParseFunctions(t *testing.T) {
 	testLiteralExpression(t, fnExp.Parameters[1], "pr2")
 }
 
+func TestAnonymousFunctions(t *testing.T) {
+	for _, test := range data.AnonymousFunctions {
+		prog, parser := getProg(test.Input)
+		checkParserErrors(parser, t)
+		checkIsProgramStmLengthValid(prog, t, 1)
+		ans := prog.ToString()
+		if ans != test.Expected {
+			t.Fatalf("wrong result for %q expected=%s and got=%s", test.Input, test.Expected, ans)
+		}
+	}
+}
+
+func TestFunctionParams(t *testing.T) {
+	for _, test := range data.FunctionParams {
+		prog, parser := getProg(test.Input)
+		checkParserErrors(parser, t)
+		checkIsProgramStmLengthValid(prog, t, 1)
+		ans := prog.ToString()
+		if ans != test.Expected {
+			t.Fatalf("wrong result for %q expected=%s and got=%s", test.Input, test.Expected, ans)
+		}
+	}
+
+	for _, test := range data.FunctionParamsErrors {
+		_, parser := getProg(test.Input)
+		errors := parser.Errors()
+		if len(errors) == 0 {
+			t.Fatalf("expected a parsing error for %q", test.Input)
+		}
+		if errors[0].Message != test.Expected {
+			t.Fatalf("wrong error for %q expected=%q and got=%q", test.Input, test.Expected, errors[0].Message)
+		}
+	}
+}
+
 func TestFnCallExpression(t *testing.T) {
 
 	input := data.FunctionCall
@@ -443,6 +778,49 @@ func TestArrayIndexExp(t *testing.T) {
 	}
 }
 
+func TestParseMapLit(t *testing.T) {
+	pr, parser := getProg(data.MapLit)
+	checkParserErrors(parser, t)
+	checkIsProgramStmLengthValid(pr, t, 1)
+
+	defStm := pr.Statements[0].(*ast.DefStatement)
+	mapLit, ok := defStm.Value.(*ast.MapLiteral)
+	if !ok {
+		t.Fatalf("defStm.Value is not of type *ast.MapLiteral instead got %T", defStm.Value)
+	}
+	if len(mapLit.Pairs) != 3 {
+		t.Fatalf("the map literal should have 3 pairs instead got %d", len(mapLit.Pairs))
+	}
+	if mapLit.ToString() != "{one:1,two:(1+1),3:true}" {
+		t.Fatalf("wrong map literal string got %s", mapLit.ToString())
+	}
+
+	pr, parser = getProg(data.EmptyMapLit)
+	checkParserErrors(parser, t)
+	if len(pr.Statements[0].(*ast.DefStatement).Value.(*ast.MapLiteral).Pairs) != 0 {
+		t.Fatalf("the empty map literal should have no pairs")
+	}
+
+	pr, parser = getProg(data.IndexAssign)
+	checkParserErrors(parser, t)
+	assign, ok := pr.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.AssignmentExpression)
+	if !ok {
+		t.Fatalf("the expression is not of type *ast.AssignmentExpression instead got %T",
+			pr.Statements[0].(*ast.ExpressionStatement).Expression)
+	}
+	if _, ok := assign.Left.(*ast.IndexExpression); !ok {
+		t.Fatalf("assign.Left is not of type *ast.IndexExpression instead got %T", assign.Left)
+	}
+
+	// a { starting a statement is a block not a map
+	pr, parser = getProg(data.BareBlockStm)
+	checkParserErrors(parser, t)
+	checkIsProgramStmLengthValid(pr, t, 1)
+	if _, ok := pr.Statements[0].(*ast.BlockStm); !ok {
+		t.Fatalf("pr.Statements[0] is not of type *ast.BlockStm instead got %T", pr.Statements[0])
+	}
+}
+
 func TestClassExpression(t *testing.T) {
 	input := data.ClassExp
 
@@ -458,6 +836,40 @@ func TestClassExpression(t *testing.T) {
 	}
 
 	fmt.Printf("program is %s", pr.ToString())
+
+	for _, test := range data.ClassConstructorParams {
+		pr, parser = getProg(test.Input)
+		checkParserErrors(parser, t)
+		if pr.ToString() != test.Expected {
+			t.Fatalf("wrong result for %q expected=%s and got=%s", test.Input, test.Expected, pr.ToString())
+		}
+	}
+}
+
+func TestLoopControlStatements(t *testing.T) {
+	pr, parser := getProg(data.LoopControl)
+
+	checkParserErrors(parser, t)
+	checkIsProgramStmLengthValid(pr, t, 1)
+
+	forExp := pr.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.ForLoopExpression)
+	if _, ok := forExp.Body.Statements[1].(*ast.BreakStatement); !ok {
+		t.Fatalf("forExp.Body.Statements[1] is not of type *ast.BreakStatement instead got %T",
+			forExp.Body.Statements[1])
+	}
+
+	ifExp := forExp.Body.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.IfExpression)
+	if _, ok := ifExp.Body.Statements[0].(*ast.ContinueStatement); !ok {
+		t.Fatalf("ifExp.Body.Statements[0] is not of type *ast.ContinueStatement instead got %T",
+			ifExp.Body.Statements[0])
+	}
+
+	for _, input := range data.LoopControlOutsideLoop {
+		_, parser := getProg(input)
+		if len(parser.Errors()) != 1 {
+			t.Fatalf("expected exactly one parsing error for %q instead got %d", input, len(parser.Errors()))
+		}
+	}
 }
 
 // Tests helper functions
@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"fmt"
+
+	ast "github.com/houcine7/JIPL/internal/AST"
+	"github.com/houcine7/JIPL/internal/debug"
+	"github.com/houcine7/JIPL/internal/token"
+)
+
+// the names defined in a scope of the parsed program, true for the constants.
+// it's used to report the assignments and redefinitions of constants that are
+// detectable before running the program
+type scope map[string]bool
+
+func (p *Parser) pushScope() {
+	p.scopes = append(p.scopes, scope{})
+}
+
+func (p *Parser) popScope() {
+	p.scopes = p.scopes[:len(p.scopes)-1]
+}
+
+func (p *Parser) declare(name string, isConst bool, tok token.Token) {
+	current := p.scopes[len(p.scopes)-1]
+	if current[name] {
+		p.addError(&Error{Message: fmt.Sprintf("cannot redefine constant %s", name), Token: tok,
+			Code: debug.CONSTANT_ERROR})
+		return
+	}
+	current[name] = isConst
+}
+
+func (p *Parser) declarePattern(pattern ast.Expression, isConst bool) {
+	switch pat := pattern.(type) {
+	case *ast.Identifier:
+		if pat.Value != "_" {
+			p.declare(pat.Value, isConst, pat.Token)
+		}
+	case *ast.SpreadExpression:
+		p.declarePattern(pat.Value, isConst)
+	case *ast.ArrayLiteral:
+		for _, element := range pat.Values {
+			p.declarePattern(element, isConst)
+		}
+	}
+}
+
+// reports the assignments to a name that resolves to a constant
+func (p *Parser) checkAssignable(target ast.Expression) {
+	ident, ok := target.(*ast.Identifier)
+	if !ok {
+		return
+	}
+	for i := len(p.scopes) - 1; i >= 0; i-- {
+		if isConst, declared := p.scopes[i][ident.Value]; declared {
+			if isConst {
+				p.addError(&Error{Message: fmt.Sprintf("cannot assign to constant %s", ident.Value),
+					Token: ident.Token, Code: debug.CONSTANT_ERROR})
+			}
+			return
+		}
+	}
+}
+
+// a param with a default value is parsed as an assignment before it's known to be a param,
+// the param is a new name so the error reported for assigning it is dropped
+func (p *Parser) dropAssignmentError(param *ast.Identifier) {
+	for i, err := range p.errors {
+		if err.Code == debug.CONSTANT_ERROR && err.Token.Pos == param.Token.Pos {
+			p.errors = append(p.errors[:i], p.errors[i+1:]...)
+			return
+		}
+	}
+}
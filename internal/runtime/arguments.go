@@ -0,0 +1,104 @@
+package runtime
+
+import (
+	"fmt"
+
+	ast "github.com/houcine7/JIPL/internal/AST"
+	"github.com/houcine7/JIPL/internal/debug"
+	"github.com/houcine7/JIPL/internal/types"
+)
+
+// evaluates the arguments of a function call, the named arguments are placed
+// at the position of the param with the same name. a param that isn't given leaves
+// a nil hole filled later by its default value
+func evalArguments(node *ast.FunctionCall, function types.ObjectJIPL, ctx *types.Context) ([]types.ObjectJIPL, *debug.Error) {
+	firstNamed := len(node.Arguments)
+	for idx, arg := range node.Arguments {
+		if _, ok := arg.(*ast.NamedArgument); ok {
+			firstNamed = idx
+			break
+		}
+	}
+
+	args, err := evalExpressions(node.Arguments[:firstNamed], ctx)
+	if err != debug.NOERROR {
+		return nil, err
+	}
+	if firstNamed == len(node.Arguments) {
+		return args, debug.NOERROR
+	}
+
+	name := node.Function.ToString()
+	params, ok := paramNames(function)
+	if !ok {
+		return nil, debug.NewError(fmt.Sprintf("%s doesn't accept named arguments", name))
+	}
+
+	for _, arg := range node.Arguments[firstNamed:] {
+		namedArg := arg.(*ast.NamedArgument)
+		position := indexOf(params, namedArg.Name.Value)
+		if position == -1 {
+			return nil, debug.NewError(fmt.Sprintf("%s got an unexpected named argument %s", name, namedArg.Name.Value))
+		}
+		if position < len(args) && args[position] != nil {
+			return nil, debug.NewError(fmt.Sprintf("%s got multiple values for the argument %s", name, namedArg.Name.Value))
+		}
+
+		val, err := Eval(namedArg.Value, ctx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		for len(args) <= position {
+			args = append(args, nil)
+		}
+		args[position] = val
+	}
+
+	if _, isBuiltIn := function.(*types.BuiltIn); isBuiltIn {
+		// builtins don't have default values
+		for position, arg := range args {
+			if arg == nil {
+				return nil, debug.NewError(fmt.Sprintf("%s missing the argument %s", name, params[position]))
+			}
+		}
+	}
+
+	return args, debug.NOERROR
+}
+
+// the names of the params that can be given as named arguments
+func paramNames(function types.ObjectJIPL) ([]string, bool) {
+	switch fn := function.(type) {
+	case *types.Function:
+		return identifierNames(fn.Params), true
+	case *types.Class:
+		if fn.Constructor == nil {
+			return nil, false
+		}
+		return identifierNames(fn.Constructor.Parameters), true
+	case *types.BuiltIn:
+		return fn.Params, fn.Params != nil
+	default:
+		return nil, false
+	}
+}
+
+// the destructured params are left empty, they can't be given by name
+func identifierNames(identifiers []*ast.Identifier) []string {
+	names := make([]string, len(identifiers))
+	for i, ident := range identifiers {
+		if ident != nil {
+			names[i] = ident.Value
+		}
+	}
+	return names
+}
+
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
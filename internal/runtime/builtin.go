@@ -2,6 +2,10 @@ package runtime
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
 
 	"github.com/houcine7/JIPL/internal/debug"
 	"github.com/houcine7/JIPL/internal/types"
@@ -12,9 +16,15 @@ var builtins = map[string]*types.BuiltIn{
 		for _, arg := range args {
 			fmt.Println(arg.ToString())
 		}
-		return nil, debug.NOERROR
+		return types.UNDEFIEND, debug.NOERROR
 	}},
-	"length": {Fn: func(args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	"isDefined": {Params: []string{"value"}, Fn: func(args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+		if len(args) != 1 {
+			return nil, debug.NewError(fmt.Sprintf("the arguments of the isDefined function should be exactly one instead got %d", len(args)))
+		}
+		return types.BoolToObJIPL(args[0] != types.UNDEFIEND), debug.NOERROR
+	}},
+	"length": {Params: []string{"value"}, Fn: func(args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
 
 		if len(args) != 1 {
 			return nil, debug.NewError(fmt.Sprintf("the arguments of the length function should be exactly one instead got %d", len(args)))
@@ -23,8 +33,170 @@ var builtins = map[string]*types.BuiltIn{
 		switch t := args[0].(type) {
 		case *types.String:
 			return &types.Integer{Val: len(t.Val)}, debug.NOERROR
+		case *types.Array:
+			return &types.Integer{Val: len(t.Elements)}, debug.NOERROR
+		case *types.Map:
+			return &types.Integer{Val: len(t.Keys)}, debug.NOERROR
+		case *types.Range:
+			return &types.Integer{Val: t.Len()}, debug.NOERROR
 		default:
 			return nil, debug.NewError(fmt.Sprintf("the argument of type %T doesn't have the length function", t))
 		}
 	}},
+	"range": {Fn: func(args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+		if len(args) < 1 || len(args) > 3 {
+			return nil, debug.NewError(fmt.Sprintf("the arguments of the range function should be between one and three instead got %d", len(args)))
+		}
+
+		bounds := make([]int, len(args))
+		for i, arg := range args {
+			if arg.GetType() != types.T_INTEGER {
+				return nil, debug.NewError(fmt.Sprintf("the arguments of the range function should be integers instead got %s", arg.GetType()))
+			}
+			bound, err := toInt(arg)
+			if err != debug.NOERROR {
+				return nil, err
+			}
+			bounds[i] = bound
+		}
+
+		// range(end), range(start, end) or range(start, end, step)
+		start, end, step := 0, bounds[0], 1
+		if len(bounds) > 1 {
+			start, end = bounds[0], bounds[1]
+		}
+		if len(bounds) == 3 {
+			step = bounds[2]
+		}
+		if step == 0 {
+			return nil, debug.NewError("the step of the range function can't be zero")
+		}
+
+		return &types.Range{Start: start, End: end, Step: step}, debug.NOERROR
+	}},
+	"keys": {Params: []string{"map"}, Fn: func(args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+		m, err := mapArg("keys", args, 1)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		keys := []types.ObjectJIPL{}
+		for _, pair := range m.Entries() {
+			keys = append(keys, pair.Key)
+		}
+		return &types.Array{Elements: keys}, debug.NOERROR
+	}},
+	"values": {Params: []string{"map"}, Fn: func(args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+		m, err := mapArg("values", args, 1)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		values := []types.ObjectJIPL{}
+		for _, pair := range m.Entries() {
+			values = append(values, pair.Value)
+		}
+		return &types.Array{Elements: values}, debug.NOERROR
+	}},
+	"has": {Params: []string{"map", "key"}, Fn: func(args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+		m, err := mapArg("has", args, 2)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		key, err := hashKey(args[1])
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		_, ok := m.Get(key)
+		return types.BoolToObJIPL(ok), debug.NOERROR
+	}},
+	"delete": {Params: []string{"map", "key"}, Fn: func(args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+		m, err := mapArg("delete", args, 2)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		key, err := hashKey(args[1])
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		return types.BoolToObJIPL(m.Delete(key)), debug.NOERROR
+	}},
+	"int": {Params: []string{"value"}, Fn: func(args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+		if len(args) != 1 {
+			return nil, debug.NewError(fmt.Sprintf("the arguments of the int function should be exactly one instead got %d", len(args)))
+		}
+
+		switch t := args[0].(type) {
+		case *types.Integer, *types.BigInteger:
+			return t, debug.NOERROR
+		case *types.Float:
+			// truncates toward zero
+			return floatToInt(t.Val)
+		case *types.String:
+			val, ok := new(big.Int).SetString(strings.TrimSpace(t.Val), 10)
+			if !ok {
+				return nil, debug.NewError(fmt.Sprintf("couldn't convert the string %q to an integer", t.Val))
+			}
+			return normalizeBigInt(val), debug.NOERROR
+		default:
+			return nil, debug.NewError(fmt.Sprintf("couldn't convert a value of type %s to an integer", t.GetType()))
+		}
+	}},
+	"float": {Params: []string{"value"}, Fn: func(args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+		if len(args) != 1 {
+			return nil, debug.NewError(fmt.Sprintf("the arguments of the float function should be exactly one instead got %d", len(args)))
+		}
+
+		switch t := args[0].(type) {
+		case *types.Float:
+			return t, debug.NOERROR
+		case *types.Integer, *types.BigInteger:
+			return &types.Float{Val: toFloat(t)}, debug.NOERROR
+		case *types.String:
+			val, err := strconv.ParseFloat(strings.TrimSpace(t.Val), 64)
+			if err != nil {
+				return nil, debug.NewError(fmt.Sprintf("couldn't convert the string %q to a float", t.Val))
+			}
+			return &types.Float{Val: val}, debug.NOERROR
+		default:
+			return nil, debug.NewError(fmt.Sprintf("couldn't convert a value of type %s to a float", t.GetType()))
+		}
+	}},
+	"round": {Params: []string{"x", "digits"}, Fn: func(args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+		if len(args) != 1 && len(args) != 2 {
+			return nil, debug.NewError(fmt.Sprintf("the arguments of the round function should be one or two instead got %d", len(args)))
+		}
+		if !isNumeric(args[0]) {
+			return nil, debug.NewError(fmt.Sprintf("the first argument of the round function should be a number instead got %s", args[0].GetType()))
+		}
+
+		// round(x) rounds to the nearest integer
+		if len(args) == 1 {
+			if args[0].GetType() == types.T_INTEGER {
+				return args[0], debug.NOERROR
+			}
+			return floatToInt(math.Round(toFloat(args[0])))
+		}
+
+		// round(x, digits) keeps the given number of decimal digits
+		if args[1].GetType() != types.T_INTEGER {
+			return nil, debug.NewError(fmt.Sprintf("the digits of the round function should be an integer instead got %s", args[1].GetType()))
+		}
+		digits, err := toInt(args[1])
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		pow := math.Pow(10, float64(digits))
+		return &types.Float{Val: math.Round(toFloat(args[0])*pow) / pow}, debug.NOERROR
+	}},
+}
+
+// checks the arguments of the map builtins, the map is always the first argument
+func mapArg(name string, args []types.ObjectJIPL, expected int) (*types.Map, *debug.Error) {
+	if len(args) != expected {
+		return nil, debug.NewError(fmt.Sprintf("the arguments of the %s function should be exactly %d instead got %d", name, expected, len(args)))
+	}
+	m, ok := args[0].(*types.Map)
+	if !ok {
+		return nil, debug.NewError(fmt.Sprintf("the first argument of the %s function should be a MAP instead got %s", name, args[0].GetType()))
+	}
+	return m, debug.NOERROR
 }
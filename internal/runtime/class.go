@@ -0,0 +1,63 @@
+package runtime
+
+import (
+	ast "github.com/houcine7/JIPL/internal/AST"
+	"github.com/houcine7/JIPL/internal/debug"
+	"github.com/houcine7/JIPL/internal/types"
+)
+
+func evalClassLiteral(node *ast.ClassLiteral, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
+	class := &types.Class{
+		Name:        node.ClassName.Value,
+		Constructor: node.Constructor,
+		Fields:      node.DataMembers,
+		Methods:     node.Methods,
+		Ctx:         ctx,
+	}
+	if err := define(ctx, class.Name, class, false); err != debug.NOERROR {
+		return nil, err
+	}
+	return class, debug.NOERROR
+}
+
+/*
+* Creates a new instance of the given class:
+* data members are evaluated for every instance then the constructor
+* is called with the 'this' binding
+ */
+func instantiate(class *types.Class, args []types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	inst := &types.Instance{
+		Class:  class,
+		Fields: types.NewContextWithOuter(class.Ctx),
+	}
+
+	// the scope shared by the constructor and the methods of the instance
+	// it holds the 'this' binding and the bound methods
+	selfCtx := types.NewContextWithOuter(inst.Fields)
+	selfCtx.Set("this", inst)
+	inst.Self = selfCtx
+
+	for _, method := range class.Methods {
+		selfCtx.Set(method.Name.Value, newFunction(method, selfCtx))
+	}
+
+	for _, field := range class.Fields {
+		val, err := Eval(field.Value, selfCtx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		inst.Fields.Set(field.Name.Value, val)
+	}
+
+	if class.Constructor == nil {
+		return inst, debug.NOERROR
+	}
+
+	// the args are checked by checkArguments before the class is instantiated
+	_, err := invokeFunction(newFunction(class.Constructor, selfCtx), args)
+	if err != debug.NOERROR {
+		return nil, err
+	}
+
+	return inst, debug.NOERROR
+}
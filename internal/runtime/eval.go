@@ -2,6 +2,8 @@ package runtime
 
 import (
 	"fmt"
+	"math"
+	"strings"
 
 	ast "github.com/houcine7/JIPL/internal/AST"
 	"github.com/houcine7/JIPL/internal/debug"
@@ -9,14 +11,23 @@ import (
 )
 
 func Eval(node ast.Node, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
+	val, err := evalNode(node, ctx)
+	// the error takes the position of the innermost node that failed
+	if err != debug.NOERROR && !err.Pos.IsValid() && node != nil {
+		err.Pos = node.Pos()
+	}
+	return val, err
+}
+
+func evalNode(node ast.Node, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
 	switch node := node.(type) {
 	case *ast.Program:
 		return evalAllProgramStatements(node.Statements, ctx)
 	case *ast.ExpressionStatement:
 		return Eval(node.Expression, ctx)
 	case *ast.ReturnStatement:
-		if ctx.Outer == nil {
-			return nil, debug.NewError("return statements can only be used insed a function") // TODO:  to be tested
+		if !ctx.InFunction {
+			return nil, debug.NewError("return statements can only be used inside a function")
 		}
 		value, err := Eval(node.ReturnValue, ctx)
 		if err != debug.NOERROR {
@@ -28,60 +39,288 @@ func Eval(node ast.Node, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
 		if err != debug.NOERROR {
 			return nil, err
 		}
-		ctx.Set(node.Name.Value, val)
+		if node.Pattern != nil {
+			return val, definePattern(ctx, node.Pattern, val, node.IsConst())
+		}
+		if err := define(ctx, node.Name.Value, val, node.IsConst()); err != debug.NOERROR {
+			return nil, err
+		}
 
 		return val, err
+	case *ast.BreakStatement:
+		return types.BREAK, debug.NOERROR
+	case *ast.ContinueStatement:
+		return types.CONTINUE, debug.NOERROR
 	case *ast.Identifier:
 		return evalIdentifier(node, ctx)
 	case *ast.ForLoopExpression:
 		return evalForLoopExpression(node, ctx)
+	case *ast.WhileLoopExpression:
+		return evalWhileLoopExpression(node, ctx)
+	case *ast.DoWhileLoopExpression:
+		return evalDoWhileLoopExpression(node, ctx)
+	case *ast.ForInLoopExpression:
+		return evalForInLoopExpression(node, ctx)
 	case *ast.IfExpression:
 		return evalIfExpression(node, ctx)
+	case *ast.MatchExpression:
+		return evalMatchExpression(node, ctx)
 	case *ast.FunctionExp:
-		ctx.Set(node.Name.Value, &types.Function{Name: node.Name.Value, Params: node.Parameters,
-			Body: node.FnBody, Ctx: ctx})
-		return &types.Function{Name: node.Name.Value, Params: node.Parameters,
-			Body: node.FnBody, Ctx: ctx}, debug.NOERROR
-	case *ast.FunctionCall:
-		function, err := Eval(node.Function, ctx)
-		if err != debug.NOERROR {
-			return nil, err
-		}
-		args, err := evalExpressions(node.Arguments, ctx)
-		if err != debug.NOERROR {
-			return nil, err
-		}
-		return applyFunction(function, args)
+		return evalFunctionExpression(node, ctx)
+	case *ast.SpreadExpression:
+		return nil, debug.NewError("the spread operator can only be used in function calls and array literals")
+	case *ast.NamedArgument:
+		return nil, debug.NewError("named arguments can only be used in function calls")
+	case *ast.ClassLiteral:
+		return evalClassLiteral(node, ctx)
+	case *ast.AssignmentExpression:
+		return evalAssignmentExpression(node, ctx)
+	case *ast.FunctionCall, *ast.MemberExpression, *ast.IndexExpression:
+		val, _, err := evalChain(node.(ast.Expression), ctx)
+		return val, err
 	case *ast.BlockStm:
-		return evalABlockStatements(node.Statements, ctx)
+		// every block has its own scope, its definitions shadow the outer ones
+		return evalABlockStatements(node.Statements, types.NewContextWithOuter(ctx))
 	case *ast.IntegerLiteral:
+		if node.Big != nil {
+			return &types.BigInteger{Val: node.Big}, debug.NOERROR
+		}
 		return &types.Integer{Val: node.Value}, debug.NOERROR
+	case *ast.FloatLiteral:
+		return &types.Float{Val: node.Value}, debug.NOERROR
 	case *ast.StringLiteral:
 		return &types.String{Val: node.Value}, debug.NOERROR
 	case *ast.BooleanExp:
 		return types.BoolToObJIPL(node.Value), debug.NOERROR
+	case *ast.NullLiteral:
+		return types.UNDEFIEND, debug.NOERROR
+	case *ast.ArrayLiteral:
+		elements, err := evalExpressions(node.Values, ctx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		return &types.Array{Elements: elements}, debug.NOERROR
+	case *ast.MapLiteral:
+		return evalMapLiteral(node, ctx)
 	case *ast.PrefixExpression:
-		operand, _ := Eval(node.Right, ctx)
+		operand, err := Eval(node.Right, ctx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
 		return evalPrefixExpression(node.Operator, operand)
 	case *ast.PostfixExpression:
-		operand, _ := Eval(node.Left, ctx)
-		return evalPostfixExpression(node.Operator, operand)
+		return evalPostfixUpdate(node, ctx)
 	case *ast.InfixExpression:
-		leftOperand, _ := Eval(node.Left, ctx)
-		rightOperand, _ := Eval(node.Right, ctx)
+		if node.Operator == "&&" || node.Operator == "||" {
+			return evalLogicalExpression(node, ctx)
+		}
+		if node.Operator == "??" {
+			return evalNullishExpression(node, ctx)
+		}
+		leftOperand, err := Eval(node.Left, ctx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		rightOperand, err := Eval(node.Right, ctx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
 		return evalInfixExpression(node.Operator, leftOperand, rightOperand)
 	default:
 		return nil, debug.NewError("unknown ast node type")
 	}
 }
 
+// creates the function closure, only named functions are bound in the current context
+func evalFunctionExpression(node *ast.FunctionExp, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
+	function := newFunction(node, ctx)
+	if node.Name != nil {
+		if err := define(ctx, function.Name, function, false); err != debug.NOERROR {
+			return nil, err
+		}
+	}
+	return function, debug.NOERROR
+}
+
+// defines a binding in the current context, constants can't be redefined
+// and the builtins can't be shadowed unless the context allows it
+func define(ctx *types.Context, name string, val types.ObjectJIPL, isConst bool) *debug.Error {
+	if ctx.Consts[name] {
+		return debug.NewError(fmt.Sprintf("cannot redefine constant %s", name))
+	}
+	// the nested scopes can shadow the builtins, only the global definitions
+	// could hide them from the whole program by accident
+	if _, ok := builtins[name]; ok && ctx.Outer == nil && !ctx.AllowShadowing {
+		return debug.NewError(fmt.Sprintf("cannot redefine builtin %s", name))
+	}
+	if isConst {
+		ctx.SetConst(name, val)
+	} else {
+		ctx.Set(name, val)
+	}
+	return debug.NOERROR
+}
+
+// defines the names bound by destructuring the value with the pattern
+func definePattern(ctx *types.Context, pattern *ast.ArrayLiteral, val types.ObjectJIPL, isConst bool) *debug.Error {
+	bindings := types.NewContextWithOuter(ctx)
+	if err := bindPattern(pattern, val, bindings); err != debug.NOERROR {
+		return err
+	}
+	// the names are defined in the order of the pattern so the errors are reproducible
+	for _, name := range patternNames(pattern, nil) {
+		bound, ok := bindings.Store[name]
+		if !ok {
+			continue // a type or class name checks the value without binding it
+		}
+		if err := define(ctx, name, bound, isConst); err != debug.NOERROR {
+			return err
+		}
+	}
+	return debug.NOERROR
+}
+
+// updates an existing binding, constants are read-only
+func assignIdentifier(ctx *types.Context, name string, val types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	if ctx.IsConst(name) {
+		return nil, debug.NewError(fmt.Sprintf("cannot assign to constant %s", name))
+	}
+	// update the binding in the nearest enclosing scope that declares it
+	if _, ok := ctx.Assign(name, val); !ok {
+		return nil, debug.NewCodedError(debug.UNDEFINED_NAME, fmt.Sprintf("assignment to undeclared identifier: %s", name))
+	}
+	return val, debug.NOERROR
+}
+
+/*
+* Evaluates the member accesses, indexes and calls of a chain like a?.b.c(1)[0],
+* ended reports that an optional member found an undefined object: the rest
+* of the chain is skipped and the whole chain is undefined
+ */
+func evalChain(node ast.Expression, ctx *types.Context) (val types.ObjectJIPL, ended bool, err *debug.Error) {
+	switch node := node.(type) {
+	case *ast.MemberExpression:
+		object, ended, err := evalChainLink(node.Object, ctx)
+		if err != debug.NOERROR || ended {
+			return object, ended, err
+		}
+		if node.Optional && object == types.UNDEFIEND {
+			return types.UNDEFIEND, true, debug.NOERROR
+		}
+		val, err = evalMemberExpression(object, node.Property.Value)
+		return val, false, err
+	case *ast.IndexExpression:
+		left, ended, err := evalChainLink(node.Left, ctx)
+		if err != debug.NOERROR || ended {
+			return left, ended, err
+		}
+		index, err := Eval(node.Index, ctx)
+		if err != debug.NOERROR {
+			return nil, false, err
+		}
+		val, err = evalIndexExpression(left, index)
+		return val, false, err
+	case *ast.FunctionCall:
+		function, ended, err := evalChainLink(node.Function, ctx)
+		if err != debug.NOERROR || ended {
+			return function, ended, err
+		}
+		args, err := evalArguments(node, function, ctx)
+		if err != debug.NOERROR {
+			return nil, false, err
+		}
+		val, err = callFunction(node, function, args, ctx)
+		return val, false, err
+	default:
+		val, err = Eval(node, ctx)
+		return val, false, err
+	}
+}
+
+// evaluates the object of a link of a chain, the errors take its position like in Eval
+func evalChainLink(node ast.Expression, ctx *types.Context) (types.ObjectJIPL, bool, *debug.Error) {
+	val, ended, err := evalChain(node, ctx)
+	if err != debug.NOERROR && !err.Pos.IsValid() {
+		err.Pos = node.Pos()
+	}
+	return val, ended, err
+}
+
+// creates a function object closing over the given context
+func newFunction(node *ast.FunctionExp, ctx *types.Context) *types.Function {
+	function := &types.Function{Params: node.Parameters, Defaults: node.Defaults, Patterns: node.Patterns,
+		Rest: node.Rest, Body: node.FnBody, Ctx: ctx}
+	if node.Name != nil {
+		function.Name = node.Name.Value
+	}
+	return function
+}
+
+// the calls nested deeper are an error, it stops the infinite recursions
+const MAX_CALL_DEPTH = 10000
+
+// applies a function called by the program, the call stays on the call stack of the
+// context while it's evaluated and the errors it returns carry the stack
+func callFunction(node *ast.FunctionCall, function types.ObjectJIPL, args []types.ObjectJIPL, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
+	name, traced := frameName(node, function)
+	if !traced {
+		return applyFunction(function, args)
+	}
+	calls := ctx.Calls
+	if len(calls.Frames) >= MAX_CALL_DEPTH {
+		return nil, debug.NewError(fmt.Sprintf("maximum call depth of %d exceeded", MAX_CALL_DEPTH))
+	}
+	// a wrong call is an error of the caller, it's reported before the callee is entered
+	if err := checkArguments(function, args); err != debug.NOERROR {
+		return nil, err
+	}
+
+	// the frame points to the start of the callee, not to the ( of the call
+	calls.Frames = append(calls.Frames, debug.Frame{Function: name, Pos: ast.StartPos(node.Function)})
+	defer func() { calls.Frames = calls.Frames[:len(calls.Frames)-1] }()
+
+	result, err := invokeFunction(function, args)
+	if err != debug.NOERROR && err.Stack == nil {
+		err.Stack = append([]debug.Frame(nil), calls.Frames...)
+	}
+	return result, err
+}
+
+// the name of the frame of a call, the builtins aren't traced
+func frameName(node *ast.FunctionCall, function types.ObjectJIPL) (string, bool) {
+	switch fn := function.(type) {
+	case *types.Function:
+		if ident, ok := node.Function.(*ast.Identifier); ok && fn.Name == "" {
+			// an anonymous function is named by the variable holding it
+			return ident.Value, true
+		}
+		return functionName(fn), true
+	case *types.Class:
+		return fn.Name, true
+	default:
+		return "", false
+	}
+}
+
 func applyFunction(function types.ObjectJIPL, args []types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	if err := checkArguments(function, args); err != debug.NOERROR {
+		return nil, err
+	}
+	return invokeFunction(function, args)
+}
+
+// applies a function to arguments already checked by checkArguments
+func invokeFunction(function types.ObjectJIPL, args []types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
 	switch fn := function.(type) {
 	case *types.Function:
 
-		appendedCtx := appedCtx(fn, args)
+		appendedCtx, err := appedCtx(fn, args)
+		if err != debug.NOERROR {
+			return nil, err
+		}
 
-		eval, err := Eval(fn.Body, appendedCtx)
+		// the body shares the scope of the params
+		eval, err := evalABlockStatements(fn.Body.Statements, appendedCtx)
 		if err != debug.NOERROR {
 			return nil, err
 		}
@@ -90,19 +329,107 @@ func applyFunction(function types.ObjectJIPL, args []types.ObjectJIPL) (types.Ob
 		// No return statement for the fn body
 	case *types.BuiltIn:
 		return fn.Fn(args...)
+	case *types.Class:
+		return instantiate(fn, args)
 	default:
 		return nil, debug.NewError("function not defined")
 	}
 }
 
-func appedCtx(fn *types.Function, args []types.ObjectJIPL) *types.Context {
+// binds the args to the params of the function in a new context, the args should be checked first
+func appedCtx(fn *types.Function, args []types.ObjectJIPL) (*types.Context, *debug.Error) {
 	ctx := types.NewContextWithOuter(fn.Ctx)
+	ctx.InFunction = true
 
 	for i, param := range fn.Params {
-		ctx.Set(param.Value, args[i])
+		if param == nil {
+			pattern := fn.Patterns[i]
+			if err := bindPattern(pattern, args[i], ctx); err != debug.NOERROR {
+				return nil, debug.NewError(fmt.Sprintf("%s parameter %s: %s", functionName(fn), pattern.ToString(), err.Msg))
+			}
+			continue
+		}
+		if i < len(args) && args[i] != nil {
+			ctx.Set(param.Value, args[i])
+			continue
+		}
+		// the default values are evaluated on each call and can use the previous params
+		val, err := Eval(fn.Defaults[i], ctx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		ctx.Set(param.Value, val)
+	}
+
+	if fn.Rest != nil {
+		rest := []types.ObjectJIPL{}
+		if len(args) > len(fn.Params) {
+			rest = append(rest, args[len(fn.Params):]...)
+		}
+		ctx.Set(fn.Rest.Value, &types.Array{Elements: rest})
+	}
+
+	return ctx, debug.NOERROR
+}
+
+// checks the args of a call against the params of the called function or of the
+// constructor of the called class, the builtins check their own args
+func checkArguments(function types.ObjectJIPL, args []types.ObjectJIPL) *debug.Error {
+	switch fn := function.(type) {
+	case *types.Function:
+		if err := checkArity(fn, len(args)); err != debug.NOERROR {
+			return err
+		}
+		for i, param := range fn.Params {
+			if i < len(args) && args[i] != nil {
+				continue
+			}
+			// a hole left by the named arguments, the destructured params are required
+			if param == nil {
+				return debug.NewError(fmt.Sprintf("%s missing the argument %s", functionName(fn), fn.Patterns[i].ToString()))
+			}
+			if i >= len(fn.Defaults) || fn.Defaults[i] == nil {
+				return debug.NewError(fmt.Sprintf("%s missing the argument %s", functionName(fn), param.Value))
+			}
+		}
+	case *types.Class:
+		if fn.Constructor == nil && len(args) != 0 {
+			return debug.NewError(fmt.Sprintf("class %s has no constructor, expected 0 arguments instead got %d",
+				fn.Name, len(args)))
+		}
+		if fn.Constructor != nil {
+			return checkArguments(newFunction(fn.Constructor, fn.Ctx), args)
+		}
+	}
+	return debug.NOERROR
+}
+
+// checks the number of args against the required params, the params with default values
+// and the rest param of the function
+func checkArity(fn *types.Function, argsCount int) *debug.Error {
+	required := len(fn.Params)
+	for required > 0 && required <= len(fn.Defaults) && fn.Defaults[required-1] != nil {
+		required--
+	}
+
+	name := functionName(fn)
+
+	switch {
+	case required == len(fn.Params) && fn.Rest == nil && argsCount != required:
+		return debug.NewError(fmt.Sprintf("%s expects %d arguments instead got %d", name, required, argsCount))
+	case argsCount < required:
+		return debug.NewError(fmt.Sprintf("%s expects at least %d arguments instead got %d", name, required, argsCount))
+	case fn.Rest == nil && argsCount > len(fn.Params):
+		return debug.NewError(fmt.Sprintf("%s expects at most %d arguments instead got %d", name, len(fn.Params), argsCount))
 	}
+	return debug.NOERROR
+}
 
-	return ctx
+func functionName(fn *types.Function) string {
+	if fn.Name == "" {
+		return "anonymous function"
+	}
+	return fn.Name
 }
 
 func uwrapReturnValue(obj types.ObjectJIPL) types.ObjectJIPL {
@@ -115,6 +442,15 @@ func uwrapReturnValue(obj types.ObjectJIPL) types.ObjectJIPL {
 func evalExpressions(node []ast.Expression, ctx *types.Context) ([]types.ObjectJIPL, *debug.Error) {
 	var result []types.ObjectJIPL
 	for _, exp := range node {
+		if spread, ok := exp.(*ast.SpreadExpression); ok {
+			elements, err := evalSpreadExpression(spread, ctx)
+			if err != debug.NOERROR {
+				return nil, err
+			}
+			result = append(result, elements...)
+			continue
+		}
+
 		evaluated, err := Eval(exp, ctx)
 
 		if err != debug.NOERROR {
@@ -125,6 +461,26 @@ func evalExpressions(node []ast.Expression, ctx *types.Context) ([]types.ObjectJ
 	return result, debug.NOERROR
 }
 
+// the elements of the spread array
+func evalSpreadExpression(node *ast.SpreadExpression, ctx *types.Context) ([]types.ObjectJIPL, *debug.Error) {
+	val, err := Eval(node.Value, ctx)
+	if err != debug.NOERROR {
+		return nil, err
+	}
+	switch val := val.(type) {
+	case *types.Array:
+		return val.Elements, debug.NOERROR
+	case *types.Range:
+		elements := make([]types.ObjectJIPL, val.Len())
+		for idx := range elements {
+			elements[idx] = &types.Integer{Val: val.At(idx)}
+		}
+		return elements, debug.NOERROR
+	default:
+		return nil, debug.NewError(fmt.Sprintf("spread operand should be an ARRAY instead got %s", val.GetType()))
+	}
+}
+
 func evalIdentifier(node *ast.Identifier, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
 	val, ok := ctx.Get(node.Value)
 
@@ -135,27 +491,210 @@ func evalIdentifier(node *ast.Identifier, ctx *types.Context) (types.ObjectJIPL,
 	if ok {
 		return builtin, debug.NOERROR
 	}
-	return nil, debug.NewError(fmt.Sprintf("identifier not found: %s", node.Value))
+	return nil, debug.NewCodedError(debug.UNDEFINED_NAME, fmt.Sprintf("identifier not found: %s", node.Value))
+}
+
+func evalAssignmentExpression(node *ast.AssignmentExpression, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
+	val, err := Eval(node.AssignmentValue, ctx)
+	if err != debug.NOERROR {
+		return nil, err
+	}
+
+	switch left := node.Left.(type) {
+	case *ast.Identifier:
+		if node.Operator != "=" {
+			current, err := evalIdentifier(left, ctx)
+			if err != debug.NOERROR {
+				return nil, err
+			}
+			val, err = evalCompoundAssignment(node.Operator, current, val)
+			if err != debug.NOERROR {
+				return nil, err
+			}
+		}
+		return assignIdentifier(ctx, left.Value, val)
+	case *ast.MemberExpression:
+		object, err := Eval(left.Object, ctx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		if node.Operator != "=" {
+			current, err := evalMemberExpression(object, left.Property.Value)
+			if err != debug.NOERROR {
+				return nil, err
+			}
+			val, err = evalCompoundAssignment(node.Operator, current, val)
+			if err != debug.NOERROR {
+				return nil, err
+			}
+		}
+		return assignMember(object, left.Property.Value, val)
+	case *ast.IndexExpression:
+		object, err := Eval(left.Left, ctx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		index, err := Eval(left.Index, ctx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		if node.Operator != "=" {
+			current, err := evalIndexExpression(object, index)
+			if err != debug.NOERROR {
+				return nil, err
+			}
+			val, err = evalCompoundAssignment(node.Operator, current, val)
+			if err != debug.NOERROR {
+				return nil, err
+			}
+		}
+		return assignIndex(object, index, val)
+	default:
+		return nil, debug.NewError(fmt.Sprintf("invalid assignment target: %s", node.Left.ToString()))
+	}
+}
+
+// computes the new value of a compound assignment (e.g. a += b is a = a + b)
+func evalCompoundAssignment(operator string, current, val types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	return evalInfixExpression(strings.TrimSuffix(operator, "="), current, val)
+}
+
+// the operands without a value are undefined
+func orUndefined(obj types.ObjectJIPL) types.ObjectJIPL {
+	if obj == nil {
+		return types.UNDEFIEND
+	}
+	return obj
+}
+
+// the truthiness of the values used as conditions: false, undefined, zero,
+// the empty string, the empty array and the empty map are falsy, any other value is truthy
+func isTruthy(obj types.ObjectJIPL) bool {
+	switch val := obj.(type) {
+	case nil, *types.Undefined:
+		return false
+	case *types.Boolean:
+		return val.Val
+	case *types.Integer:
+		return val.Val != 0
+	case *types.BigInteger:
+		return val.Val.Sign() != 0
+	case *types.Float:
+		return val.Val != 0 && !math.IsNaN(val.Val)
+	case *types.String:
+		return val.Val != ""
+	case *types.Array:
+		return len(val.Elements) != 0
+	case *types.Map:
+		return len(val.Keys) != 0
+	case *types.Range:
+		return val.Len() != 0
+	default:
+		return true
+	}
 }
 
 func evalIfExpression(ifExp *ast.IfExpression, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
-	condition, _ := Eval(ifExp.Condition, ctx)
-	if condition == types.TRUE {
+	condition, err := Eval(ifExp.Condition, ctx)
+	if err != debug.NOERROR {
+		return nil, err
+	}
+	if isTruthy(condition) {
 		return Eval(ifExp.Body, ctx)
 	}
+	if ifExp.ElseIf != nil {
+		return evalIfExpression(ifExp.ElseIf, ctx)
+	}
 	if ifExp.ElseBody != nil {
 		return Eval(ifExp.ElseBody, ctx)
 	}
-	return nil, debug.NOERROR
+	return types.UNDEFIEND, debug.NOERROR
+}
+
+func evalIndexExpression(left, index types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	switch {
+	case left.GetType() == types.T_ARRAY && index.GetType() == types.T_INTEGER:
+		return evalArrayIndexExpression(left.(*types.Array), index)
+	case left.GetType() == types.T_MAP:
+		return evalMapIndexExpression(left.(*types.Map), index)
+	case left.GetType() == types.T_RANGE && index.GetType() == types.T_INTEGER:
+		return evalRangeIndexExpression(left.(*types.Range), index)
+	default:
+		return nil, debug.NewError(fmt.Sprintf("index operator not supported: %s[%s]", left.GetType(), index.GetType()))
+	}
+}
+
+func evalArrayIndexExpression(arr *types.Array, index types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	idx, err := arrayIndex(arr, index)
+	if err != debug.NOERROR {
+		return nil, err
+	}
+	return arr.Elements[idx], debug.NOERROR
+}
+
+func evalRangeIndexExpression(r *types.Range, index types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	intIndex, ok := index.(*types.Integer)
+	if !ok || intIndex.Val < 0 || intIndex.Val >= r.Len() {
+		return nil, debug.NewError(fmt.Sprintf("range index out of range: %s with length %d", index.ToString(), r.Len()))
+	}
+	return &types.Integer{Val: r.At(intIndex.Val)}, debug.NOERROR
+}
+
+// checks that the index is an integer within the bounds of the array
+func arrayIndex(arr *types.Array, index types.ObjectJIPL) (int, *debug.Error) {
+	if index.GetType() != types.T_INTEGER {
+		return 0, debug.NewError(fmt.Sprintf("array index should be an INTEGER instead got %s", index.GetType()))
+	}
+	if toBigInt(index).Sign() < 0 {
+		return 0, debug.NewError(fmt.Sprintf("negative array index: %s", index.ToString()))
+	}
+	intIndex, ok := index.(*types.Integer)
+	if !ok || intIndex.Val >= len(arr.Elements) {
+		return 0, debug.NewError(fmt.Sprintf("array index out of range: %s with length %d", index.ToString(), len(arr.Elements)))
+	}
+	return intIndex.Val, debug.NOERROR
+}
+
+// assigns arr[index] = val or map[key] = val
+func assignIndex(object, index, val types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	switch obj := object.(type) {
+	case *types.Array:
+		idx, err := arrayIndex(obj, index)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		obj.Elements[idx] = val
+		return val, debug.NOERROR
+	case *types.Map:
+		key, err := hashKey(index)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		return obj.Set(key, val), debug.NOERROR
+	default:
+		return nil, debug.NewError(fmt.Sprintf("index assignment not supported: %s[%s]", object.GetType(), index.GetType()))
+	}
 }
 
 func evalInfixExpression(operator string, leftOperand, rightOperand types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	leftOperand, rightOperand = orUndefined(leftOperand), orUndefined(rightOperand)
+
+	// any two values can be compared, the values of different types are not equal
+	// except the numbers. the other operators are strict about the types of the operands
+	if operator == "==" || operator == "!=" {
+		equal := objectsEqual(leftOperand, rightOperand)
+		return types.BoolToObJIPL(equal == (operator == "==")), debug.NOERROR
+	}
 
 	if leftOperand.GetType() == types.T_INTEGER &&
 		rightOperand.GetType() == types.T_INTEGER {
 		return evalIntInfixExpression(operator, leftOperand, rightOperand)
 	}
 
+	if isNumeric(leftOperand) && isNumeric(rightOperand) {
+		return evalFloatInfixExpression(operator, leftOperand, rightOperand)
+	}
+
 	if leftOperand.GetType() == types.T_BOOLEAN &&
 		rightOperand.GetType() == types.T_BOOLEAN {
 		return evalBoolInfixExpression(operator, leftOperand, rightOperand)
@@ -166,7 +705,111 @@ func evalInfixExpression(operator string, leftOperand, rightOperand types.Object
 		return evlStringInfix(operator, leftOperand, rightOperand)
 	}
 
-	return nil, debug.NewError(fmt.Sprintf("type mismatch: %s %s %s", leftOperand.GetType(), operator, rightOperand.GetType()))
+	if leftOperand.GetType() == types.T_ARRAY &&
+		rightOperand.GetType() == types.T_ARRAY {
+		return evalArrayInfixExpression(operator, leftOperand, rightOperand)
+	}
+
+	if leftOperand.GetType() == types.T_MAP &&
+		rightOperand.GetType() == types.T_MAP {
+		return evalMapInfixExpression(operator, leftOperand, rightOperand)
+	}
+
+	return nil, debug.NewCodedError(debug.TYPE_MISMATCH, fmt.Sprintf("type mismatch: %s %s %s", leftOperand.GetType(), operator, rightOperand.GetType()))
+}
+
+func evalArrayInfixExpression(operator string, left, right types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	arrObjLeft := left.(*types.Array)
+	arrObjRight := right.(*types.Array)
+	switch operator {
+	case "+":
+		elements := make([]types.ObjectJIPL, 0, len(arrObjLeft.Elements)+len(arrObjRight.Elements))
+		elements = append(elements, arrObjLeft.Elements...)
+		elements = append(elements, arrObjRight.Elements...)
+		return &types.Array{Elements: elements}, debug.NOERROR
+	case "==":
+		return types.BoolToObJIPL(objectsEqual(arrObjLeft, arrObjRight)), debug.NOERROR
+	case "!=":
+		return types.BoolToObJIPL(!objectsEqual(arrObjLeft, arrObjRight)), debug.NOERROR
+	default:
+		return nil, debug.NewError("unknown operator")
+	}
+}
+
+// structural equality used to compare arrays element by element
+func objectsEqual(left, right types.ObjectJIPL) bool {
+	return equalObjects(left, right, map[comparedPair]bool{})
+}
+
+// two containers being compared, comparing them again inside
+// themselves is a cycle that can't make them different
+type comparedPair struct {
+	left, right types.ObjectJIPL
+}
+
+func equalObjects(left, right types.ObjectJIPL, comparing map[comparedPair]bool) bool {
+	if isNumeric(left) && isNumeric(right) &&
+		(left.GetType() == types.T_FLOAT || right.GetType() == types.T_FLOAT) {
+		return toFloat(left) == toFloat(right)
+	}
+	if left.GetType() != right.GetType() {
+		return false
+	}
+	switch l := left.(type) {
+	case *types.Integer, *types.BigInteger:
+		return toBigInt(l).Cmp(toBigInt(right)) == 0
+	case *types.String:
+		return l.Val == right.(*types.String).Val
+	case *types.Boolean:
+		return l.Val == right.(*types.Boolean).Val
+	case *types.Undefined:
+		return true
+	case *types.Array:
+		r := right.(*types.Array)
+		if len(l.Elements) != len(r.Elements) {
+			return false
+		}
+		pair := comparedPair{l, r}
+		if comparing[pair] {
+			return true
+		}
+		comparing[pair] = true
+		defer delete(comparing, pair)
+		for i := range l.Elements {
+			if !equalObjects(l.Elements[i], r.Elements[i], comparing) {
+				return false
+			}
+		}
+		return true
+	case *types.Map:
+		r := right.(*types.Map)
+		if len(l.Keys) != len(r.Keys) {
+			return false
+		}
+		compared := comparedPair{l, r}
+		if comparing[compared] {
+			return true
+		}
+		comparing[compared] = true
+		defer delete(comparing, compared)
+		for hash, pair := range l.Pairs {
+			other, ok := r.Pairs[hash]
+			if !ok || !equalObjects(pair.Value, other.Value, comparing) {
+				return false
+			}
+		}
+		return true
+	case *types.Range:
+		// ranges are equal when they produce the same integers
+		r := right.(*types.Range)
+		length := l.Len()
+		if length != r.Len() {
+			return false
+		}
+		return length == 0 || (l.Start == r.Start && (length == 1 || l.Step == r.Step))
+	default:
+		return left == right
+	}
 }
 
 func evlStringInfix(operator string, left, right types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
@@ -183,6 +826,41 @@ func evlStringInfix(operator string, left, right types.ObjectJIPL) (types.Object
 		return nil, debug.NewError("unknown operator")
 	}
 }
+
+// evaluates && and || lazily, the right operand is only evaluated
+// when the left one doesn't decide the result. the result is the boolean
+// of the truthiness of the operands
+func evalLogicalExpression(node *ast.InfixExpression, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
+	left, err := Eval(node.Left, ctx)
+	if err != debug.NOERROR {
+		return nil, err
+	}
+	if node.Operator == "&&" && !isTruthy(left) {
+		return types.FALSE, debug.NOERROR
+	}
+	if node.Operator == "||" && isTruthy(left) {
+		return types.TRUE, debug.NOERROR
+	}
+
+	right, err := Eval(node.Right, ctx)
+	if err != debug.NOERROR {
+		return nil, err
+	}
+	return types.BoolToObJIPL(isTruthy(right)), debug.NOERROR
+}
+
+// evaluates a ?? b, the right operand is only evaluated when the left one is undefined
+func evalNullishExpression(node *ast.InfixExpression, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
+	left, err := Eval(node.Left, ctx)
+	if err != debug.NOERROR {
+		return nil, err
+	}
+	if left != types.UNDEFIEND {
+		return left, debug.NOERROR
+	}
+	return Eval(node.Right, ctx)
+}
+
 func evalBoolInfixExpression(operator string, left, right types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
 	boolObjRight := right.(*types.Boolean)
 	boolObjLeft := left.(*types.Boolean)
@@ -191,18 +869,17 @@ func evalBoolInfixExpression(operator string, left, right types.ObjectJIPL) (typ
 		return types.BoolToObJIPL(boolObjLeft.Val == boolObjRight.Val), debug.NOERROR
 	case "!=":
 		return types.BoolToObJIPL(boolObjLeft.Val != boolObjRight.Val), debug.NOERROR
-	case "&&":
-		return types.BoolToObJIPL(boolObjLeft.Val && boolObjRight.Val), debug.NOERROR
-	case "||":
-		return types.BoolToObJIPL(boolObjLeft.Val || boolObjRight.Val), debug.NOERROR
 	default:
 		return nil, debug.NewError("unknown operator")
 	}
 }
 
 func evalIntInfixExpression(operator string, left, right types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
-	intObjRight := right.(*types.Integer)
-	intObjLeft := left.(*types.Integer)
+	intObjRight, okRight := right.(*types.Integer)
+	intObjLeft, okLeft := left.(*types.Integer)
+	if !okLeft || !okRight || intOverflows(operator, intObjLeft.Val, intObjRight.Val) {
+		return evalBigIntInfixExpression(operator, left, right)
+	}
 	switch operator {
 	case "+":
 		return &types.Integer{Val: intObjLeft.Val + intObjRight.Val}, debug.NOERROR
@@ -211,8 +888,15 @@ func evalIntInfixExpression(operator string, left, right types.ObjectJIPL) (type
 	case "*":
 		return &types.Integer{Val: intObjLeft.Val * intObjRight.Val}, debug.NOERROR
 	case "/":
+		if intObjRight.Val == 0 {
+			return nil, debug.NewError("division by zero")
+		}
+		// the division of integers is an integer truncated toward zero
 		return &types.Integer{Val: intObjLeft.Val / intObjRight.Val}, debug.NOERROR
 	case "%":
+		if intObjRight.Val == 0 {
+			return nil, debug.NewError("modulo by zero")
+		}
 		return &types.Integer{Val: intObjLeft.Val % intObjRight.Val}, debug.NOERROR
 	case "==":
 		return types.BoolToObJIPL(intObjLeft.Val == intObjRight.Val), debug.NOERROR
@@ -232,37 +916,248 @@ func evalIntInfixExpression(operator string, left, right types.ObjectJIPL) (type
 }
 
 func evalForLoopExpression(forLoop *ast.ForLoopExpression, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
+	// the loop variables are local to the loop
+	loopCtx := types.NewContextWithOuter(ctx)
+
+	if forLoop.InitStm != nil {
+		_, err := Eval(forLoop.InitStm, loopCtx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+	}
 
-	_, err := Eval(forLoop.InitStm, ctx)
+	for {
+		if forLoop.Condition != nil {
+			condition, err := Eval(forLoop.Condition, loopCtx)
+			if err != debug.NOERROR {
+				return nil, err
+			}
+			if !isTruthy(condition) {
+				break
+			}
+		}
+
+		iterationEval, err := Eval(forLoop.Body, loopCtx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		if result, stop := loopControl(iterationEval); stop {
+			return result, debug.NOERROR
+		}
 
+		if forLoop.PostIteration != nil {
+			_, err := Eval(forLoop.PostIteration, loopCtx)
+			if err != debug.NOERROR {
+				return nil, err
+			}
+		}
+	}
+	return types.UNDEFIEND, debug.NOERROR
+}
+
+func evalWhileLoopExpression(whileLoop *ast.WhileLoopExpression, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
+	loopCtx := types.NewContextWithOuter(ctx)
+
+	for {
+		condition, err := Eval(whileLoop.Condition, loopCtx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		if !isTruthy(condition) {
+			break
+		}
+
+		iterationEval, err := Eval(whileLoop.Body, loopCtx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		if result, stop := loopControl(iterationEval); stop {
+			return result, debug.NOERROR
+		}
+	}
+	return types.UNDEFIEND, debug.NOERROR
+}
+
+func evalDoWhileLoopExpression(doWhileLoop *ast.DoWhileLoopExpression, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
+	loopCtx := types.NewContextWithOuter(ctx)
+
+	for {
+		iterationEval, err := Eval(doWhileLoop.Body, loopCtx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		if result, stop := loopControl(iterationEval); stop {
+			return result, debug.NOERROR
+		}
+
+		condition, err := Eval(doWhileLoop.Condition, loopCtx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		if !isTruthy(condition) {
+			break
+		}
+	}
+	return types.UNDEFIEND, debug.NOERROR
+}
+
+func evalForInLoopExpression(forIn *ast.ForInLoopExpression, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
+	iterable, err := Eval(forIn.Iterable, ctx)
 	if err != debug.NOERROR {
-		fmt.Println("error while evaluating the init statement", err)
 		return nil, err
 	}
+	next, err := iterationValues(iterable)
+	if err != debug.NOERROR {
+		return nil, err
+	}
+
+	for el, ok := next(); ok; el, ok = next() {
+		// every iteration gets its own binding of the loop variable
+		loopCtx := types.NewContextWithOuter(ctx)
+		loopCtx.Set(forIn.Variable.Value, el)
+
+		iterationEval, err := Eval(forIn.Body, loopCtx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		if result, stop := loopControl(iterationEval); stop {
+			return result, debug.NOERROR
+		}
+	}
+	return types.UNDEFIEND, debug.NOERROR
+}
 
-	// the condition
-	condition, _ := Eval(forLoop.Condition, ctx)
+// the values a for in loop iterates over: the elements of an array,
+// the characters of a string, the keys of a map, the integers of a range
+// or the integers from 0 to n-1. next reports false when no values are left,
+// the integers are produced one by one instead of being collected up front
+func iterationValues(iterable types.ObjectJIPL) (func() (types.ObjectJIPL, bool), *debug.Error) {
+	switch it := iterable.(type) {
+	case *types.Integer, *types.BigInteger:
+		n, err := toInt(it)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		return rangeValues(&types.Range{Start: 0, End: n, Step: 1}), debug.NOERROR
+	case *types.Range:
+		return rangeValues(it), debug.NOERROR
+	}
 
-	for condition == types.TRUE {
-		iterationEval, _ := Eval(forLoop.Body, ctx)
-		returnEval, ok := iterationEval.(*types.Return)
-		if ok {
-			return returnEval.Val, debug.NOERROR
+	elements, err := iterationElements(iterable)
+	if err != debug.NOERROR {
+		return nil, err
+	}
+	idx := 0
+	return func() (types.ObjectJIPL, bool) {
+		if idx >= len(elements) {
+			return nil, false
 		}
+		idx++
+		return elements[idx-1], true
+	}, debug.NOERROR
+}
 
-		postEval, _ := Eval(forLoop.PostIteration, ctx)
-		postFix, ok := forLoop.PostIteration.(*ast.PostfixExpression)
+func rangeValues(r *types.Range) func() (types.ObjectJIPL, bool) {
+	idx, length := 0, r.Len()
+	return func() (types.ObjectJIPL, bool) {
+		if idx >= length {
+			return nil, false
+		}
+		idx++
+		return &types.Integer{Val: r.At(idx - 1)}, true
+	}
+}
 
-		if ok {
-			ctx.Set(postFix.Left.(*ast.Identifier).Value,
-				postEval)
-			condition, _ = Eval(forLoop.Condition, ctx)
+// the elements of the collections a for in loop iterates over
+func iterationElements(iterable types.ObjectJIPL) ([]types.ObjectJIPL, *debug.Error) {
+	switch it := iterable.(type) {
+	case *types.Array:
+		// copy the elements so the loop body can modify the array
+		elements := make([]types.ObjectJIPL, len(it.Elements))
+		copy(elements, it.Elements)
+		return elements, debug.NOERROR
+	case *types.String:
+		var elements []types.ObjectJIPL
+		for _, char := range it.Val {
+			elements = append(elements, &types.String{Val: string(char)})
+		}
+		return elements, debug.NOERROR
+	case *types.Map:
+		var elements []types.ObjectJIPL
+		for _, pair := range it.Entries() {
+			elements = append(elements, pair.Key)
 		}
+		return elements, debug.NOERROR
+	default:
+		return nil, debug.NewError(fmt.Sprintf("cannot iterate over a value of type %s", iterable.GetType()))
+	}
+}
+
+// checks the result of a loop iteration and reports whether the loop should stop,
+// a return value is handed back so the enclosing function returns
+func loopControl(iterationEval types.ObjectJIPL) (types.ObjectJIPL, bool) {
+	if iterationEval != nil && iterationEval.GetType() == types.T_RETURN {
+		return iterationEval, true
+	}
+	return types.UNDEFIEND, iterationEval == types.BREAK
+}
+
+// evaluates ++ and -- and updates the operand when it's a variable or a field
+func evalPostfixUpdate(node *ast.PostfixExpression, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
+	switch left := node.Left.(type) {
+	case *ast.Identifier:
+		operand, err := evalIdentifier(left, ctx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		result, err := evalPostfixExpression(node.Operator, operand)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		return assignIdentifier(ctx, left.Value, result)
+	case *ast.MemberExpression:
+		object, err := Eval(left.Object, ctx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		operand, err := evalMemberExpression(object, left.Property.Value)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		result, err := evalPostfixExpression(node.Operator, operand)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		return assignMember(object, left.Property.Value, result)
+	case *ast.IndexExpression:
+		object, err := Eval(left.Left, ctx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		index, err := Eval(left.Index, ctx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		operand, err := evalIndexExpression(object, index)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		result, err := evalPostfixExpression(node.Operator, operand)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		return assignIndex(object, index, result)
+	default:
+		operand, err := Eval(node.Left, ctx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		return evalPostfixExpression(node.Operator, operand)
 	}
-	return nil, debug.NOERROR
 }
 
 func evalPostfixExpression(operator string, operand types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	operand = orUndefined(operand)
 	switch operator {
 	case "--":
 		return evalDecrementPostfix(operand)
@@ -274,23 +1169,29 @@ func evalPostfixExpression(operator string, operand types.ObjectJIPL) (types.Obj
 }
 
 func evalIncrementPostfix(operand types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
-	if operand.GetType() != types.T_INTEGER {
-		return nil, debug.NewError("operand is not an integer")
+	switch num := operand.(type) {
+	case *types.Integer, *types.BigInteger:
+		return evalIntInfixExpression("+", num, &types.Integer{Val: 1})
+	case *types.Float:
+		return &types.Float{Val: num.Val + 1}, debug.NOERROR
+	default:
+		return nil, debug.NewError("operand is not a number")
 	}
-	intObj := operand.(*types.Integer)
-	return &types.Integer{Val: intObj.Val + 1}, debug.NOERROR
 }
 
 func evalDecrementPostfix(operand types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
-	if operand.GetType() != types.T_INTEGER {
-		return nil, debug.NewError("operand is not an integer")
+	switch num := operand.(type) {
+	case *types.Integer, *types.BigInteger:
+		return evalIntInfixExpression("-", num, &types.Integer{Val: 1})
+	case *types.Float:
+		return &types.Float{Val: num.Val - 1}, debug.NOERROR
+	default:
+		return nil, debug.NewError("operand is not a number")
 	}
-	intObj := operand.(*types.Integer)
-	return &types.Integer{Val: intObj.Val - 1}, debug.NOERROR
 }
 
 func evalAllProgramStatements(stms []ast.Statement, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
-	var result types.ObjectJIPL
+	var result types.ObjectJIPL = types.UNDEFIEND
 	var err = debug.NOERROR
 
 	for _, stm := range stms {
@@ -307,7 +1208,7 @@ func evalAllProgramStatements(stms []ast.Statement, ctx *types.Context) (types.O
 }
 
 func evalABlockStatements(stms []ast.Statement, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
-	var result types.ObjectJIPL
+	var result types.ObjectJIPL = types.UNDEFIEND
 	var err = debug.NOERROR
 
 	for _, stm := range stms {
@@ -315,7 +1216,9 @@ func evalABlockStatements(stms []ast.Statement, ctx *types.Context) (types.Objec
 		if err != debug.NOERROR {
 			return nil, err
 		}
-		if result != nil && result.GetType() == types.T_RETURN {
+		if result != nil && (result.GetType() == types.T_RETURN ||
+			result == types.BREAK || result == types.CONTINUE) {
+			// stop the block and let the enclosing function or loop handle it
 			return result, debug.NOERROR
 		}
 	}
@@ -323,6 +1226,7 @@ func evalABlockStatements(stms []ast.Statement, ctx *types.Context) (types.Objec
 }
 
 func evalPrefixExpression(operator string, operand types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	operand = orUndefined(operand)
 	switch operator {
 	case "!":
 		return evalComplementPrefix(operand)
@@ -334,21 +1238,16 @@ func evalPrefixExpression(operator string, operand types.ObjectJIPL) (types.Obje
 }
 
 func evalMinusPrefix(operand types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
-	if operand.GetType() != types.T_INTEGER {
-		return nil, debug.NewError("operand is not an integer")
+	switch num := operand.(type) {
+	case *types.Integer, *types.BigInteger:
+		return evalIntInfixExpression("-", &types.Integer{Val: 0}, num)
+	case *types.Float:
+		return &types.Float{Val: -num.Val}, debug.NOERROR
+	default:
+		return nil, debug.NewError("operand is not a number")
 	}
-	intObj := operand.(*types.Integer)
-	return &types.Integer{Val: -intObj.Val}, debug.NOERROR
 }
 
 func evalComplementPrefix(operand types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
-	if operand.GetType() != types.T_BOOLEAN {
-		return nil, debug.NewError("operand is not a boolean")
-	}
-	boolObj := operand.(*types.Boolean)
-	if boolObj.Val {
-		return types.FALSE, debug.NOERROR
-	}
-
-	return types.TRUE, debug.NOERROR
+	return types.BoolToObJIPL(!isTruthy(operand)), debug.NOERROR
 }
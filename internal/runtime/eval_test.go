@@ -1,8 +1,11 @@
 package runtime
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/houcine7/JIPL/internal/debug"
 	"github.com/houcine7/JIPL/internal/lexer"
 	"github.com/houcine7/JIPL/internal/parser"
 	"github.com/houcine7/JIPL/internal/types"
@@ -61,6 +64,475 @@ func TestClosure(t *testing.T) {
 	}
 }
 
+func TestArrayEval(t *testing.T) {
+	for _, test := range arrayEvalData {
+		evaluated := getEvaluated(test.input)
+		arr, ok := evaluated.(*types.Array)
+		if !ok {
+			t.Fatalf("the evaluated object is not of type *types.Array, instead got %T", evaluated)
+		}
+		if len(arr.Elements) != len(test.expected) {
+			t.Fatalf("wrong number of elements expected %d instead got %d", len(test.expected), len(arr.Elements))
+		}
+		for i, el := range arr.Elements {
+			testIntegerObject(t, el, test.expected[i])
+		}
+	}
+
+	for _, test := range arrayIndexData {
+		evaluated := getEvaluated(test.input)
+		testIntegerObject(t, evaluated, test.expected)
+	}
+
+	for _, test := range arrayEqualityData {
+		evaluated := getEvaluated(test.input)
+		testBooleanObject(t, evaluated, test.expected)
+	}
+}
+
+func TestArrayIndexErrors(t *testing.T) {
+	for _, test := range arrayIndexErrData {
+		err := getEvalError(test.input)
+		if err == debug.NOERROR {
+			t.Fatalf("expected an error for input %q", test.input)
+		}
+		if err.Msg != test.expected {
+			t.Fatalf("wrong error message expected %q instead got %q", test.expected, err.Msg)
+		}
+	}
+}
+
+func TestClassInstances(t *testing.T) {
+	evaluated := getEvaluated(classEvalData)
+	arr, ok := evaluated.(*types.Array)
+	if !ok {
+		t.Fatalf("the evaluated object is not of type *types.Array, instead got %T", evaluated)
+	}
+
+	expected := []int{20, 3}
+	for i, el := range arr.Elements {
+		inst, ok := el.(*types.Instance)
+		if !ok {
+			t.Fatalf("the element %d is not of type *types.Instance, instead got %T", i, el)
+		}
+		if inst.Class.Name != "Counter" {
+			t.Fatalf("the instance class name is not as expected %s instead got %s", "Counter", inst.Class.Name)
+		}
+		count, _ := inst.Fields.Get("count")
+		testIntegerObject(t, count, expected[i])
+	}
+
+	if arr.Elements[0].ToString() != "Counter{count: 20, step: 5}" {
+		t.Fatalf("wrong instance string representation got %s", arr.Elements[0].ToString())
+	}
+}
+
+func TestMemberAccess(t *testing.T) {
+	for _, test := range memberEvalData {
+		evaluated := getEvaluated(test.input)
+		if evaluated == nil {
+			t.Fatalf("the evaluated object of %q is nil", test.input)
+		}
+		if evaluated.ToString() != test.expected {
+			t.Fatalf("wrong result for %q expected %s instead got %s", test.input, test.expected, evaluated.ToString())
+		}
+	}
+
+	for _, test := range memberErrData {
+		err := getEvalError(test.input)
+		if err.Msg != test.expected {
+			t.Fatalf("wrong error message expected %q instead got %q", test.expected, err.Msg)
+		}
+	}
+}
+
+func TestAssignmentEval(t *testing.T) {
+	for _, test := range assignEvalData {
+		evaluated := getEvaluated(test.input)
+		testIntegerObject(t, evaluated, test.expected)
+	}
+
+	err := getEvalError("undeclared = 5;")
+	if err.Msg != "assignment to undeclared identifier: undeclared" {
+		t.Fatalf("wrong error message for undeclared assignment, got %q", err.Msg)
+	}
+}
+
+func TestBreakContinueEval(t *testing.T) {
+	for _, test := range loopControlData {
+		evaluated := getEvaluated(test.input)
+		testIntegerObject(t, evaluated, test.expected)
+	}
+}
+
+func TestForLoopEval(t *testing.T) {
+	for _, test := range forLoopData {
+		evaluated := getEvaluated(test.input)
+		testIntegerObject(t, evaluated, test.expected)
+	}
+}
+
+func TestOtherLoopsEval(t *testing.T) {
+	for _, test := range otherLoopsData {
+		evaluated := getEvaluated(test.input)
+		testIntegerObject(t, evaluated, test.expected)
+	}
+
+	err := getEvalError("for (def x in true) { }")
+	if err.Msg != "cannot iterate over a value of type BOOLEAN" {
+		t.Fatalf("wrong error message got %q", err.Msg)
+	}
+}
+
+func TestRangeEval(t *testing.T) {
+	for _, test := range rangeEvalData {
+		evaluated := getEvaluated(test.input)
+		if evaluated == nil {
+			t.Fatalf("the evaluated object of %q is nil", test.input)
+		}
+		if evaluated.ToString() != test.expected {
+			t.Fatalf("wrong result for %q expected %s instead got %s", test.input, test.expected, evaluated.ToString())
+		}
+	}
+}
+
+func TestMapEval(t *testing.T) {
+	for _, test := range mapEvalData {
+		evaluated := getEvaluated(test.input)
+		if evaluated == nil {
+			t.Fatalf("the evaluated object of %q is nil", test.input)
+		}
+		if evaluated.ToString() != test.expected {
+			t.Fatalf("wrong result for %q expected %s instead got %s", test.input, test.expected, evaluated.ToString())
+		}
+	}
+
+	err := getEvalError("def m = {[1]: 2};")
+	if err.Msg != "unusable as map key: ARRAY" {
+		t.Fatalf("wrong error message got %q", err.Msg)
+	}
+}
+
+func TestCyclicContainersEval(t *testing.T) {
+	for _, test := range cyclicEvalData {
+		evaluated := getEvaluated(test.input)
+		if evaluated == nil {
+			t.Fatalf("the evaluated object of %q is nil", test.input)
+		}
+		if evaluated.ToString() != test.expected {
+			t.Fatalf("wrong result for %q expected %s instead got %s", test.input, test.expected, evaluated.ToString())
+		}
+	}
+}
+
+func TestFloatEval(t *testing.T) {
+	for _, test := range floatEvalData {
+		evaluated := getEvaluated(test.input)
+		if evaluated == nil {
+			t.Fatalf("the evaluated object of %q is nil", test.input)
+		}
+		if evaluated.ToString() != test.expected {
+			t.Fatalf("wrong result for %q expected %s instead got %s", test.input, test.expected, evaluated.ToString())
+		}
+	}
+
+	for _, test := range floatErrData {
+		err := getEvalError(test.input)
+		if err.Msg != test.expected {
+			t.Fatalf("wrong error message for %q expected %q instead got %q", test.input, test.expected, err.Msg)
+		}
+	}
+}
+
+func TestBigIntegerEval(t *testing.T) {
+	for _, test := range bigIntegerEvalData {
+		evaluated := getEvaluated(test.input)
+		if evaluated == nil {
+			t.Fatalf("the evaluated object of %q is nil", test.input)
+		}
+		if evaluated.ToString() != test.expected {
+			t.Fatalf("wrong result for %q expected %s instead got %s", test.input, test.expected, evaluated.ToString())
+		}
+	}
+
+	for _, test := range bigIntegerErrData {
+		err := getEvalError(test.input)
+		if err.Msg != test.expected {
+			t.Fatalf("wrong error message for %q expected %q instead got %q", test.input, test.expected, err.Msg)
+		}
+	}
+}
+
+func TestAnonymousFunctionEval(t *testing.T) {
+	for _, test := range anonymousFunctionEvalData {
+		evaluated := getEvaluated(test.input)
+		if evaluated == nil {
+			t.Fatalf("the evaluated object of %q is nil", test.input)
+		}
+		if evaluated.ToString() != test.expected {
+			t.Fatalf("wrong result for %q expected %s instead got %s", test.input, test.expected, evaluated.ToString())
+		}
+	}
+}
+
+func TestAnonymousFunctionScope(t *testing.T) {
+	program := parser.InitParser(lexer.InitLexer("function(a) { return a; }; (b) => b;")).Parse()
+	ctx := types.NewContext()
+	evaluated, err := Eval(program, ctx)
+	if err != debug.NOERROR {
+		t.Fatalf("unexpected error %s", err.Msg)
+	}
+	if _, ok := evaluated.(*types.Function); !ok {
+		t.Fatalf("the evaluated object is not a function instead got %T", evaluated)
+	}
+	if len(ctx.Store) != 0 {
+		t.Fatalf("anonymous functions shouldn't be bound in the context, got %v", ctx.Store)
+	}
+}
+
+func TestFunctionParamsEval(t *testing.T) {
+	for _, test := range functionParamsEvalData {
+		evaluated := getEvaluated(test.input)
+		if evaluated == nil {
+			t.Fatalf("the evaluated object of %q is nil", test.input)
+		}
+		if evaluated.ToString() != test.expected {
+			t.Fatalf("wrong result for %q expected %s instead got %s", test.input, test.expected, evaluated.ToString())
+		}
+	}
+
+	for _, test := range functionParamsErrData {
+		err := getEvalError(test.input)
+		if err == debug.NOERROR {
+			t.Fatalf("expected an error for input %q", test.input)
+		}
+		if err.Msg != test.expected {
+			t.Fatalf("wrong error message expected %q instead got %q", test.expected, err.Msg)
+		}
+	}
+}
+
+func TestNamedArgumentsEval(t *testing.T) {
+	for _, test := range namedArgsEvalData {
+		evaluated := getEvaluated(test.input)
+		if evaluated == nil {
+			t.Fatalf("the evaluated object of %q is nil", test.input)
+		}
+		if evaluated.ToString() != test.expected {
+			t.Fatalf("wrong result for %q expected %s instead got %s", test.input, test.expected, evaluated.ToString())
+		}
+	}
+
+	for _, test := range namedArgsErrData {
+		err := getEvalError(test.input)
+		if err == debug.NOERROR {
+			t.Fatalf("expected an error for input %q", test.input)
+		}
+		if err.Msg != test.expected {
+			t.Fatalf("wrong error message expected %q instead got %q", test.expected, err.Msg)
+		}
+	}
+}
+
+func TestElseIfAndMatchEval(t *testing.T) {
+	for _, test := range matchEvalData {
+		evaluated := getEvaluated(test.input)
+		if evaluated == nil {
+			t.Fatalf("the evaluated object of %q is nil", test.input)
+		}
+		if evaluated.ToString() != test.expected {
+			t.Fatalf("wrong result for %q expected %s instead got %s", test.input, test.expected, evaluated.ToString())
+		}
+	}
+}
+
+func TestDestructuringEval(t *testing.T) {
+	for _, test := range destructuringEvalData {
+		evaluated := getEvaluated(test.input)
+		if evaluated == nil {
+			t.Fatalf("the evaluated object of %q is nil", test.input)
+		}
+		if evaluated.ToString() != test.expected {
+			t.Fatalf("wrong result for %q expected %s instead got %s", test.input, test.expected, evaluated.ToString())
+		}
+	}
+
+	for _, test := range destructuringErrData {
+		err := getEvalError(test.input)
+		if err == debug.NOERROR {
+			t.Fatalf("expected an error for input %q", test.input)
+		}
+		if err.Msg != test.expected {
+			t.Fatalf("wrong error message expected %q instead got %q", test.expected, err.Msg)
+		}
+	}
+}
+
+func TestConstEval(t *testing.T) {
+	for _, test := range constEvalData {
+		evaluated := getEvaluated(test.input)
+		if evaluated == nil {
+			t.Fatalf("the evaluated object of %q is nil", test.input)
+		}
+		if evaluated.ToString() != test.expected {
+			t.Fatalf("wrong result for %q expected %s instead got %s", test.input, test.expected, evaluated.ToString())
+		}
+	}
+
+	for _, test := range constErrData {
+		err := getEvalError(test.input)
+		if err == debug.NOERROR {
+			t.Fatalf("expected an error for input %q", test.input)
+		}
+		if err.Msg != test.expected {
+			t.Fatalf("wrong error message expected %q instead got %q", test.expected, err.Msg)
+		}
+	}
+}
+
+func TestBuiltinShadowing(t *testing.T) {
+	program := parser.InitParser(lexer.InitLexer("def length = 5; length;")).Parse()
+
+	ctx := types.NewContext()
+	_, err := Eval(program, ctx)
+	if err == debug.NOERROR || err.Msg != "cannot redefine builtin length" {
+		t.Fatalf("expected the builtin redefinition error instead got %v", err)
+	}
+
+	ctx = types.NewContext()
+	ctx.AllowShadowing = true
+	evaluated, err := Eval(program, ctx)
+	if err != debug.NOERROR {
+		t.Fatalf("unexpected error %s", err.Msg)
+	}
+	testIntegerObject(t, evaluated, 5)
+
+	for _, test := range localShadowingData {
+		evaluated := getEvaluated(test.input)
+		testIntegerObject(t, evaluated, test.expected)
+	}
+}
+
+func TestBlockScopeEval(t *testing.T) {
+	for _, test := range blockScopeEvalData {
+		evaluated := getEvaluated(test.input)
+		if evaluated == nil {
+			t.Fatalf("the evaluated object of %q is nil", test.input)
+		}
+		if evaluated.ToString() != test.expected {
+			t.Fatalf("wrong result for %q expected %s instead got %s", test.input, test.expected, evaluated.ToString())
+		}
+	}
+
+	for _, test := range blockScopeErrData {
+		err := getEvalError(test.input)
+		if err == debug.NOERROR {
+			t.Fatalf("expected an error for input %q", test.input)
+		}
+		if err.Msg != test.expected {
+			t.Fatalf("wrong error message expected %q instead got %q", test.expected, err.Msg)
+		}
+	}
+}
+
+func TestLogicalOperatorsEval(t *testing.T) {
+	for _, test := range logicalEvalData {
+		evaluated := getEvaluated(test.input)
+		if evaluated == nil {
+			t.Fatalf("the evaluated object of %q is nil", test.input)
+		}
+		if evaluated.ToString() != test.expected {
+			t.Fatalf("wrong result for %q expected %s instead got %s", test.input, test.expected, evaluated.ToString())
+		}
+	}
+}
+
+func TestTruthinessEval(t *testing.T) {
+	for _, test := range truthinessEvalData {
+		evaluated := getEvaluated(test.input)
+		if evaluated == nil {
+			t.Fatalf("the evaluated object of %q is nil", test.input)
+		}
+		if evaluated.ToString() != test.expected {
+			t.Fatalf("wrong result for %q expected %s instead got %s", test.input, test.expected, evaluated.ToString())
+		}
+	}
+}
+
+func TestUndefinedEval(t *testing.T) {
+	for _, test := range undefinedEvalData {
+		evaluated := getEvaluated(test.input)
+		if evaluated == nil {
+			t.Fatalf("the evaluated object of %q is nil", test.input)
+		}
+		if evaluated.ToString() != test.expected {
+			t.Fatalf("wrong result for %q expected %s instead got %s", test.input, test.expected, evaluated.ToString())
+		}
+	}
+}
+
+func TestErrorPositionsEval(t *testing.T) {
+	for _, test := range errorPositionsEvalData {
+		err := getEvalError(test.input)
+		if err == debug.NOERROR {
+			t.Fatalf("expected an error for %q", test.input)
+		}
+		if err.Error() != test.expected {
+			t.Fatalf("wrong error for %q expected %q instead got %q", test.input, test.expected, err.Error())
+		}
+	}
+}
+
+func TestErrorPropagationEval(t *testing.T) {
+	for _, test := range errorPropagationEvalData {
+		err := getEvalError(test.input)
+		if err == debug.NOERROR {
+			t.Fatalf("expected an error for %q", test.input)
+		}
+		if err.Msg != test.expected {
+			t.Fatalf("wrong error message for %q expected %q instead got %q", test.input, test.expected, err.Msg)
+		}
+	}
+}
+
+func TestCallStackEval(t *testing.T) {
+	for _, test := range callStackEvalData {
+		err := getEvalError(test.input)
+		if err == debug.NOERROR {
+			t.Fatalf("expected an error for %q", test.input)
+		}
+		frames := []string{}
+		for _, frame := range err.Stack {
+			frames = append(frames, fmt.Sprintf("%s@%s", frame.Function, frame.Pos))
+		}
+		if strings.Join(frames, " ") != test.expected {
+			t.Fatalf("wrong call stack for %q expected %q instead got %q", test.input, test.expected, strings.Join(frames, " "))
+		}
+	}
+
+	program := parser.InitParser(lexer.InitLexer("def f = function(n) { return f(n + 1); }; f(0);")).Parse()
+	ctx := types.NewContext()
+	_, err := Eval(program, ctx)
+	if err.Msg != fmt.Sprintf("maximum call depth of %d exceeded", MAX_CALL_DEPTH) {
+		t.Fatalf("wrong error message for an infinite recursion got %q", err.Msg)
+	}
+	if len(err.Stack) != MAX_CALL_DEPTH {
+		t.Fatalf("wrong call stack depth expected %d instead got %d", MAX_CALL_DEPTH, len(err.Stack))
+	}
+	if len(ctx.Calls.Frames) != 0 {
+		t.Fatalf("the call stack isn't empty after the evaluation, got %d frames", len(ctx.Calls.Frames))
+	}
+}
+
+func TestTypeMismatchEval(t *testing.T) {
+	for _, test := range typeMismatchEvalData {
+		err := getEvalError(test.input)
+		if err.Msg != test.expected {
+			t.Fatalf("wrong error message for %q expected %q instead got %q", test.input, test.expected, err.Msg)
+		}
+	}
+}
+
 // ------------- TEST HELPERS  --------------
 func testBooleanObject(t *testing.T, evaluated types.ObjectJIPL, expected bool) {
 	boolObj, ok := evaluated.(*types.Boolean)
@@ -82,6 +554,15 @@ func getEvaluated(input string) types.ObjectJIPL {
 	return ev
 }
 
+func getEvalError(input string) *debug.Error {
+	l := lexer.InitLexer(input)
+	p := parser.InitParser(l)
+	program := p.Parse()
+	ctx := types.NewContext()
+	_, err := Eval(program, ctx)
+	return err
+}
+
 func testIntegerObject(t *testing.T, obj types.ObjectJIPL, expected int) {
 	intObj, ok := obj.(*types.Integer)
 	if !ok {
@@ -153,4 +634,616 @@ var (
 	def fn = outer();
 	fn();
 	`
+
+	arrayEvalData = []struct {
+		input    string
+		expected []int
+	}{
+		{"[1, 2 * 2, 3 + 3];", []int{1, 4, 6}},
+		{"[];", []int{}},
+		{"def arr = [1, 2]; arr + [3];", []int{1, 2, 3}},
+	}
+
+	arrayIndexData = []struct {
+		input    string
+		expected int
+	}{
+		{"[1, 2, 3][0];", 1},
+		{"[1, 2, 3][1 + 1];", 3},
+		{"def arr = [7, 8, 9]; arr[2];", 9},
+		{"def arr = [7, 8, 9]; arr[0] + arr[1];", 15},
+		{"length([1, 2, 3]);", 3},
+		{"[[1, 2], [3, 4]][1][0];", 3},
+	}
+
+	arrayEqualityData = []struct {
+		input    string
+		expected bool
+	}{
+		{"[1, 2] == [1, 2];", true},
+		{"[1, 2] == [2, 1];", false},
+		{"[1, [2, 3]] != [1, [2, 3]];", false},
+		{"[1] == [1, 2];", false},
+	}
+
+	arrayIndexErrData = []struct {
+		input    string
+		expected string
+	}{
+		{"[1, 2, 3][3];", "array index out of range: 3 with length 3"},
+		{"[1, 2, 3][-1];", "negative array index: -1"},
+		{"[1, 2, 3][true];", "index operator not supported: ARRAY[BOOLEAN]"},
+	}
+
+	classEvalData = `
+	class Counter {
+		def count = 0;
+		def step = 1;
+
+		constructor(start, s) {
+			count = start;
+			step = s;
+			inc();
+			inc();
+		}
+
+		function inc() {
+			count = count + step;
+			return count;
+		}
+	}
+	def first = Counter(10, 5);
+	def second = Counter(1, 1);
+	[first, second];
+	`
+
+	memberEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{`"hello".length;`, "5"},
+		{`"hello".upper();`, "HELLO"},
+		{`def s = "  JIPL "; s.trim().lower();`, "jipl"},
+		{`"a,b,c".split(",").length;`, "3"},
+		{`def arr = [1, 2]; arr.push(3); arr.join("-");`, "1-2-3"},
+		{`function add(a, b) { return a + b; } add.arity;`, "2"},
+		{`function add(a, b) { return a + b; } add.name;`, "add"},
+		{`class Point { def x = 0; def y = 0; constructor(x, y) { this.x = x; this.y = y; } function sum() { return x + y; } }
+		def p = Point(3, 4); p.x = p.x + 1; p.sum();`, "8"},
+	}
+
+	memberErrData = []struct {
+		input    string
+		expected string
+	}{
+		{`"hello".size;`, "STRING has no member 'size'"},
+		{`"hello".upper(1);`, "upper expects 0 arguments instead got 1"},
+		{`class A { def x = 1; } def a = A(); a.y;`, "instance of A has no member 'y'"},
+		{`class A { def x = 1; } def a = A(); a.y = 1;`, "instance of A has no field 'y'"},
+		{`"hello".length = 1;`, "cannot assign member 'length' of STRING"},
+	}
+
+	assignEvalData = []struct {
+		input    string
+		expected int
+	}{
+		{"def a = 1; a = a + 4; a;", 5},
+		{"def a = 1; def b = a = 7; b;", 7},
+		{"def sum = 0; for (def i = 0; i < 5; i++) { sum = sum + i; } sum;", 10},
+		{`function makeCounter() {
+			def count = 0;
+			function inc() {
+				count = count + 1;
+				return count;
+			}
+			return inc;
+		}
+		def counter = makeCounter();
+		counter();
+		counter();
+		counter();`, 3},
+		{`def total = 1;
+		function shadow() {
+			def total = 100;
+			total = total + 1;
+			return total;
+		}
+		shadow();
+		total;`, 1},
+	}
+
+	loopControlData = []struct {
+		input    string
+		expected int
+	}{
+		{`def sum = 0;
+		for (def i = 0; i < 10; i++) {
+			if (i == 5) {
+				break;
+			}
+			if (i % 2 == 0) {
+				continue;
+			}
+			sum = sum + i;
+		}
+		sum;`, 4},
+		{`def count = 0;
+		for (def i = 0; i < 3; i++) {
+			for (def j = 0; j < 10; j++) {
+				if (j == 2) {
+					break;
+				}
+				count = count + 1;
+			}
+		}
+		count;`, 6},
+	}
+
+	forLoopData = []struct {
+		input    string
+		expected int
+	}{
+		{"def s = 0; for (def i = 0; i < 10; i = i + 2) { s += i; } s;", 20},
+		{"def i = 0; for (;;) { i++; if (i == 5) { break; } } i;", 5},
+		{"def i = 100; for (def i = 0; i < 3; i++) { } i;", 100},
+		{"def i = 0; for (i = 10; i > 0; i -= 3) { } i;", -2},
+		{"def n = 0; function step() { n += 3; } for (; n < 10; step()) { } n;", 12},
+		{"function f() { for (def i = 0; ; i++) { if (i == 3) { return i * 10; } } return -1; } f();", 30},
+		{"class P { def x = 1; } def p = P(); p.x += 4; p.x++; p.x;", 6},
+		{"def s = 0; def i = 0; for (const n = 4; i < n; i++) { s += n; } s;", 16},
+		{"def s = 0; for (def [i, j] = [0, 5]; i < j; i++) { s += j; } s;", 25},
+	}
+
+	otherLoopsData = []struct {
+		input    string
+		expected int
+	}{
+		{"def i = 0; while (i < 10) { i += 3; } i;", 12},
+		{"def i = 0; while (true) { i++; if (i == 4) { break; } } i;", 4},
+		{"def i = 100; do { i++; } while (i < 10); i;", 101},
+		{"def i = 0; do { i += 2; } while (i < 10); i;", 10},
+		{"def sum = 0; for (def x in [1, 2, 3, 4]) { if (x == 2) { continue; } sum += x; } sum;", 8},
+		{"def sum = 0; for (def i in 5) { sum += i; } sum;", 10},
+		{"def sum = 0; for (def i in range(2, 10, 3)) { sum += i; } sum;", 15},
+		{`def count = 0; for (def c in "hello") { if (c == "l") { count++; } } count;`, 2},
+		{"function find(arr, v) { def i = 0; for (def x in arr) { if (x == v) { return i; } i++; } return -1; } find([5, 6, 7], 7);", 2},
+		{"function f() { def i = 0; while (true) { i++; if (i > 2) { return i; } } } f();", 3},
+	}
+
+	rangeEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{"range(2, 10, 3);", "range(2, 10, 3)"},
+		{"[length(range(5)), length(range(2, 10, 3)), length(range(10, 0, -4)), length(range(5, 1))];", "[5, 3, 3, 0]"},
+		{"[range(2, 10, 3)[1], range(10, 0, -4)[2]];", "[5, 2]"},
+		{"[...range(3), ...range(3, 0, -1)];", "[0, 1, 2, 3, 2, 1]"},
+		{"def n = 0; for (def i in range(0, 9223372036854775807, 1000000000000000000)) { n++; } n;", "10"},
+		{"def n = 0; for (def i in 100000000000) { n++; if (n == 3) { break; } } n;", "3"},
+		{"[range(0, 10, 4) == range(0, 9, 4), range(3) == range(0, 3, 2), range(3, 3) == range(5, 1)];", "[true, false, true]"},
+		{"[!range(0), !range(2)];", "[true, false]"},
+	}
+
+	mapEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{`def m = {"a": 1, "b": 2}; m["b"];`, "2"},
+		{`def m = {"a": 1, 1: "one", true: "yes"}; m[1] + m[true];`, "oneyes"},
+		{`def m = {"a": 1}; m["missing"];`, "undefined"},
+		{`def m = {"b": 1, "a": 2}; m["c"] = 3; m["b"] += 10; m;`, "{b: 11, a: 2, c: 3}"},
+		{`def m = {"b": 1, "a": 2}; keys(m);`, "[b, a]"},
+		{`def m = {"b": 1, "a": 2}; values(m);`, "[1, 2]"},
+		{`def m = {"a": 1}; [has(m, "a"), has(m, "b")];`, "[true, false]"},
+		{`def m = {"a": 1, "b": 2}; delete(m, "a"); [length(m), has(m, "a")];`, "[1, false]"},
+		{`def m = {"x": 1, "y": 2}; def s = ""; for (def k in m) { s = s + k; } s;`, "xy"},
+		{`({"a": [1, 2]} == {"a": [1, 2]});`, "true"},
+		{`def arr = [1, 2, 3]; arr[1] = 20; arr[2]++; arr;`, "[1, 20, 4]"},
+	}
+
+	cyclicEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{`def a = [1]; a.push(a); a;`, "[1, [...]]"},
+		{`def a = [1]; a.push(a); a == a;`, "true"},
+		{`def a = [1]; a.push(a); def b = [1]; b.push(b); a == b;`, "true"},
+		{`def a = [1]; a.push(a); def b = [2]; b.push(b); a == b;`, "false"},
+		{`def a = [1]; def b = [a, a]; b;`, "[[1], [1]]"},
+		{`def m = {"a": 1}; m["k"] = m; m;`, "{a: 1, k: {...}}"},
+		{`def m = {"a": 1}; m["k"] = [m]; m == m;`, "true"},
+		{`class Node { def next = 0; } def n = Node(); n.next = n; n;`, "Node{next: Node{...}}"},
+	}
+
+	floatEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{"3.14;", "3.14"},
+		{"1.5 + 1.5;", "3.0"},
+		{"1 + 0.5;", "1.5"},
+		{"7 / 2;", "3"},
+		{"-7 / 2;", "-3"},
+		{"6 / 2;", "3"},
+		{"7 / 2.0;", "3.5"},
+		{"7.0 / 2;", "3.5"},
+		{"-2.5 * 2;", "-5.0"},
+		{"5.5 % 2;", "1.5"},
+		{"1e-9 < 0.001;", "true"},
+		{"2 == 2.0;", "true"},
+		{"[1, 2] == [1.0, 2];", "true"},
+		{"int(3.99);", "3"},
+		{`int("42") + 1;`, "43"},
+		{"float(3);", "3.0"},
+		{`float("0.5") * 4;`, "2.0"},
+		{"round(2.5);", "3"},
+		{"round(3.14159, 2);", "3.14"},
+		{"def x = 0.5; x++; x;", "1.5"},
+	}
+
+	floatErrData = []struct {
+		input    string
+		expected string
+	}{
+		{"10 / 0;", "division by zero"},
+		{"10 % 0;", "modulo by zero"},
+	}
+
+	bigIntegerEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{"9223372036854775807 + 1;", "9223372036854775808"},
+		{"-9223372036854775807 - 2;", "-9223372036854775809"},
+		{"4294967296 * 4294967296;", "18446744073709551616"},
+		{"123456789012345678901234567890;", "123456789012345678901234567890"},
+		{"(9223372036854775807 + 1) - 1;", "9223372036854775807"},
+		{"(9223372036854775807 + 1) - 1 == 9223372036854775807;", "true"},
+		{"100000000000000000000 > 9223372036854775807;", "true"},
+		{"-100000000000000000000 < 0;", "true"},
+		{"100000000000000000000 / 10;", "10000000000000000000"},
+		{"100000000000000000001 / 10;", "10000000000000000000"},
+		{"100000000000000000001 % 10;", "1"},
+		{"def x = 9223372036854775807; x++; x;", "9223372036854775808"},
+		{"-(-9223372036854775807 - 1);", "9223372036854775808"},
+		{"[100000000000000000000] == [100000000000000000000];", "true"},
+		{"def m = {100000000000000000000: 1}; m[100000000000000000000];", "1"},
+		{`int("100000000000000000000");`, "100000000000000000000"},
+		{"int(1e20);", "100000000000000000000"},
+		{"int(-1e20);", "-100000000000000000000"},
+		{"int(-9223372036854775808.0);", "-9223372036854775808"},
+		{"int(9223372036854775808.0);", "9223372036854775808"},
+		{"round(1e20);", "100000000000000000000"},
+		{"round(-2.5e19);", "-25000000000000000000"},
+		{"round(100000000000000000001);", "100000000000000000001"},
+	}
+
+	bigIntegerErrData = []struct {
+		input    string
+		expected string
+	}{
+		{"range(100000000000000000000);", "integer out of range: 100000000000000000000"},
+		{"range(0, 10, -100000000000000000000);", "integer out of range: -100000000000000000000"},
+		{"round(1.5, 100000000000000000000);", "integer out of range: 100000000000000000000"},
+		{"for (def i in 100000000000000000000) { }", "integer out of range: 100000000000000000000"},
+		{`int(float("NaN"));`, "cannot convert NaN to an integer"},
+		{`int(float("inf"));`, "cannot convert +Inf to an integer"},
+		{`round(float("-inf"));`, "cannot convert -Inf to an integer"},
+	}
+
+	anonymousFunctionEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{"def add = function(a, b) { return a + b; }; add(2, 3);", "5"},
+		{"def add = (a, b) => a + b; add(2, 3);", "5"},
+		{"def double = x => x * 2; double(21);", "42"},
+		{"((x) => x + 1)(1);", "2"},
+		{"(() => { return 7; })();", "7"},
+		{"def apply = function(f, x) { return f(x); }; apply((x) => x * x, 5);", "25"},
+		{"def adder = function(n) { return (x) => x + n; }; def add2 = adder(2); add2(40);", "42"},
+		{"def counter = function() { def c = 0; return () => { c++; return c; }; }; def next = counter(); next(); next();", "2"},
+		{"def f = (a, b) => a + b; f.arity;", "2"},
+	}
+
+	functionParamsEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{"function f(a, b = 2) { return a * b; } f(5);", "10"},
+		{"function f(a, b = 2) { return a * b; } f(5, 3);", "15"},
+		{"function f(a, b = a + 1) { return b; } f(5);", "6"},
+		{"function f(first, ...rest) { return rest; } f(1, 2, 3);", "[2, 3]"},
+		{"function f(first, ...rest) { return rest; } f(1);", "[]"},
+		{"def sum = (...nums) => { def s = 0; for (def n in nums) { s += n; } return s; }; sum(1, 2, 3, 4);", "10"},
+		{"function add(a, b, c) { return a + b + c; } def arr = [1, 2]; add(...arr, 3);", "6"},
+		{"def arr = [2, 3]; [1, ...arr, 4];", "[1, 2, 3, 4]"},
+		{"class Point { def x = 0; def y = 0; constructor(x, y = 10) { this.x = x; this.y = y; } } def p = Point(1); p.y;", "10"},
+	}
+
+	functionParamsErrData = []struct {
+		input    string
+		expected string
+	}{
+		{"function add(a, b) { return a + b; } add(1);", "add expects 2 arguments instead got 1"},
+		{"function add(a, b) { return a + b; } add(1, 2, 3);", "add expects 2 arguments instead got 3"},
+		{"function f(a, b = 2) { return a; } f();", "f expects at least 1 arguments instead got 0"},
+		{"function f(a, b = 2) { return a; } f(1, 2, 3);", "f expects at most 2 arguments instead got 3"},
+		{"((x) => x)();", "anonymous function expects 1 arguments instead got 0"},
+		{"function f(a) { return a; } f(...5);", "spread operand should be an ARRAY instead got INTEGER"},
+	}
+
+	namedArgsEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{`function connect(host, port) { return [host, port]; } connect(port = 80, host = "x");`, "[x, 80]"},
+		{`function connect(host, port) { return [host, port]; } connect("x", port = 80);`, "[x, 80]"},
+		{`function f(a, b = 2, c = 3) { return [a, b, c]; } f(1, c = 30);`, "[1, 2, 30]"},
+		{`function f(a, b = a * 10) { return b; } f(b = 5, a = 1);`, "5"},
+		{`class Point { def x = 0; def y = 0; constructor(x, y) { this.x = x; this.y = y; } } Point(y = 2, x = 1).y;`, "2"},
+		{`round(3.14159, digits = 2);`, "3.14"},
+		{`has(key = "a", map = {"a": 1});`, "true"},
+	}
+
+	namedArgsErrData = []struct {
+		input    string
+		expected string
+	}{
+		{"function f(a, b) { return a; } f(1, c = 2);", "f got an unexpected named argument c"},
+		{"function f(a, b) { return a; } f(1, a = 2);", "f got multiple values for the argument a"},
+		{"function f(a, b) { return a; } f(b = 1, b = 2);", "f got multiple values for the argument b"},
+		{"function f(a, b) { return a; } f(b = 1);", "f missing the argument a"},
+		{"round(digits = 2);", "round missing the argument x"},
+		{`range(end = 3);`, "range doesn't accept named arguments"},
+	}
+
+	matchEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{`def sign = function(x) { if (x < 0) { return "neg"; } else if (x == 0) { return "zero"; } else { return "pos"; } }; [sign(-5), sign(0), sign(5)];`, "[neg, zero, pos]"},
+		{`def x = 2; if (x == 1) { "one" } else if (x == 2) { "two" };`, "two"},
+		{`def f = (v) => match (v) { case 1, 2 => "small"; case "x" => "letter"; default => "other" }; [f(1), f(2), f("x"), f(9)];`, "[small, small, letter, other]"},
+		{`match (3) { case 1 => "one" };`, "undefined"},
+		{`match (2.0) { case 2 => "two" };`, "two"},
+		{`match (-1) { case -1 => "minus one" };`, "minus one"},
+		{`def kind = (v) => match (v) { case INTEGER => "int"; case STRING => "str"; case ARRAY => "arr"; default => "?" }; [kind(1), kind("a"), kind([]), kind(true)];`, "[int, str, arr, ?]"},
+		{`match ([1, 2, 3]) { case [a] => a; case [a, b] => a + b; case [a, _, c] => a + c };`, "4"},
+		{`match ([1, 2, 3, 4]) { case [first, ...rest] => rest };`, "[2, 3, 4]"},
+		{`match ([1, [2, 3]]) { case [1, [x, y]] => x * y };`, "6"},
+		{`match ([]) { case [x, ...rest] => "some"; case [] => "empty" };`, "empty"},
+		{`match (5) { case n => n * 2 };`, "10"},
+		{`class Dog { } class Cat { } match (Cat()) { case Dog => "dog"; case Cat => "cat" };`, "cat"},
+		{`def f = function(v) { match (v) { case 1 => { return "returned"; } } return "after"; }; f(1);`, "returned"},
+	}
+
+	destructuringEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{"def [a, b] = [1, 2]; [b, a];", "[2, 1]"},
+		{"def [first, ...rest] = [1, 2, 3]; rest;", "[2, 3]"},
+		{"def [first, ...rest] = [1]; rest;", "[]"},
+		{"def [x, [y, _]] = [1, [2, 3]]; x + y;", "3"},
+		{"def [n, INTEGER] = [\"a\", 1]; n;", "a"},
+		{"function f([a, b], c) { return a + b + c; } f([1, 2], 3);", "6"},
+		{"function f([a, b], c) { return a + b + c; } f([1, 2], c = 3);", "6"},
+		{"function f(a, [b, c]) { }; [f.arity, f];", "[2, function f(a,[b,c]){}]"},
+		{"def sum = ([a, b]) => a + b; sum([20, 22]);", "42"},
+		{"def pairs = [[1, 2], [3, 4]]; def s = 0; for (def p in pairs) { def [x, y] = p; s += x * y; } s;", "14"},
+	}
+
+	destructuringErrData = []struct {
+		input    string
+		expected string
+	}{
+		{"def [a, b] = [1, 2, 3];", "cannot destructure an array of length 3 into 2 elements"},
+		{"def [a, b, ...rest] = [1];", "cannot destructure an array of length 1 into at least 2 elements"},
+		{"def [a, b] = 5;", "cannot destructure a value of type INTEGER, expected an ARRAY"},
+		{"def [a, [b]] = [1, 2];", "cannot destructure a value of type INTEGER, expected an ARRAY"},
+		{"def [1, b] = [2, 3];", "the value 2 doesn't match the pattern 1"},
+		{"def [STRING] = [1];", "expected a value of type STRING instead got INTEGER"},
+		{"function f([a, b]) { return a; } f([1]);", "f parameter [a,b]: cannot destructure an array of length 1 into 2 elements"},
+		{"function f([a, b], c) { } f(c = 3);", "f missing the argument [a,b]"},
+		{"def [a, length, out, range] = [1, 2, 3, 4];", "cannot redefine builtin length"},
+		{"def [[out], ...length] = [[1], 2];", "cannot redefine builtin out"},
+	}
+
+	constEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{"const x = 5; x * 2;", "10"},
+		{"const [a, b] = [1, 2]; a + b;", "3"},
+		{"const arr = [1]; arr.push(2); arr;", "[1, 2]"},
+		{"const x = 1; def f = function() { def x = 2; x = 3; return x; }; [f(), x];", "[3, 1]"},
+		{"const x = 1; def f = function(x) { x = 5; return x; }; f(0);", "5"},
+	}
+
+	// the runtime checks the constants too, the parser can't see a definition that follows its use
+	constErrData = []struct {
+		input    string
+		expected string
+	}{
+		{"const x = 1; def set = function() { x = 2; }; set();", "cannot assign to constant x"},
+		{"def set = function() { x = 2; }; const x = 1; set();", "cannot assign to constant x"},
+		{"def set = function() { x++; }; const x = 1; set();", "cannot assign to constant x"},
+		{"function out() { }", "cannot redefine builtin out"},
+		{"class range { }", "cannot redefine builtin range"},
+		{"def [keys, b] = [1, 2];", "cannot redefine builtin keys"},
+	}
+
+	localShadowingData = []struct {
+		input    string
+		expected int
+	}{
+		{"function f() { def values = [1, 2]; return length(values); } f();", 2},
+		{"function f(length) { return length * 2; } f(4);", 8},
+		{"def n = 0; { def length = 3; n = length; } n + length(\"ab\");", 5},
+		{"def s = 0; for (def keys in range(3)) { s += keys; } s;", 3},
+		{"class Box { def length = 7; } Box().length;", 7},
+	}
+
+	blockScopeEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{"def x = 1; if (true) { def x = 2; } x;", "1"},
+		{"def x = 1; if (true) { x = 2; } x;", "2"},
+		{"def x = 1; { def x = 2; x = 3; } x;", "1"},
+		{"def x = 1; def y = 0; { def x = 2; y = x; } [x, y];", "[1, 2]"},
+		{"const x = 1; if (true) { const x = 2; x; }", "2"},
+		{"def s = 0; for (def i = 0; i < 3; i++) { def sq = i * i; s += sq; } s;", "5"},
+		{"def fns = []; for (def i in [1, 2, 3]) { def j = i * 10; fns.push(() => j); } def r = [fns[0](), fns[2]()]; r;", "[10, 30]"},
+		{"def f = function(x) { if (x > 0) { def r = \"pos\"; return r; } return \"neg\"; }; f(1);", "pos"},
+		{"def f = function(x) { def x = x * 2; return x; }; f(4);", "8"},
+	}
+
+	blockScopeErrData = []struct {
+		input    string
+		expected string
+	}{
+		{"if (true) { def inner = 1; } inner;", "identifier not found: inner"},
+		{"{ def inner = 1; } inner = 2;", "assignment to undeclared identifier: inner"},
+		{"while (true) { def w = 1; break; } w;", "identifier not found: w"},
+		{"if (true) { return 3; } out(\"x\");", "return statements can only be used inside a function"},
+		{"for (def i = 0; i <= 10; i++) { if (i == 3) { return 3; } }", "return statements can only be used inside a function"},
+		{"function f() { return 1; } { return f(); }", "return statements can only be used inside a function"},
+	}
+
+	logicalEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{"true && false;", "false"},
+		{"false || true;", "true"},
+		{"1 == 1 && 2 == 2;", "true"},
+		{"1 == 2 || 2 == 2 && 3 == 3;", "true"},
+		{"def x = 0; x != 0 && 10 / x > 1;", "false"},
+		{"def x = 0; x == 0 || 10 / x > 1;", "true"},
+		{"def calls = 0; def f = function() { calls++; return true; }; false && f(); true || f(); calls;", "0"},
+		{"def calls = 0; def f = function() { calls++; return true; }; true && f(); false || f(); calls;", "2"},
+		{"false && undefinedName;", "false"},
+	}
+
+	truthinessEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{"!0;", "true"},
+		{"!1;", "false"},
+		{"!-2.5;", "false"},
+		{"!0.0;", "true"},
+		{"!\"\";", "true"},
+		{"!\"jipl\";", "false"},
+		{"![];", "true"},
+		{"![0];", "false"},
+		{"!{};", "true"},
+		{"!{\"a\": 1};", "false"},
+		{"!function() {};", "false"},
+		{"!99999999999999999999;", "false"},
+		{"1 && \"a\";", "true"},
+		{"0 || [];", "false"},
+		{"def r = 0; if(\"\") { r = 1; } else if([1]) { r = 2; }; r;", "2"},
+		{"def n = 3; def c = 0; while(n) { n--; c++; }; c;", "3"},
+		{"def r = []; for(def i = 3; i; i--) { r = [...r, i]; }; r;", "[3, 2, 1]"},
+		{"1 == \"1\";", "false"},
+		{"1 != \"1\";", "true"},
+		{"1 == 1.0;", "true"},
+		{"[1, 2] == [1, 2];", "true"},
+		{"[1, 2] != [1, \"2\"];", "true"},
+		{"true == 1;", "false"},
+		{"def m = {\"a\": 1}; m == {\"a\": 1};", "true"},
+		{"\"a\" == [\"a\"];", "false"},
+	}
+
+	typeMismatchEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{"1 + \"a\";", "type mismatch: INTEGER + STRING"},
+		{"true * 2;", "type mismatch: BOOLEAN * INTEGER"},
+		{"[1] - 1;", "type mismatch: ARRAY - INTEGER"},
+		{"\"a\" < 1;", "type mismatch: STRING < INTEGER"},
+	}
+
+	undefinedEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{"null;", "undefined"},
+		{"if (false) { 1; };", "undefined"},
+		{"def x = if (false) { 1; }; x == null;", "true"},
+		{"def r = 1; while (false) { r = 2; };", "undefined"},
+		{"def f = function() {}; f();", "undefined"},
+		{"def x = null; x ?? 10;", "10"},
+		{"def x = 0; x ?? 10;", "0"},
+		{"def x = false; x ?? true;", "false"},
+		{`def m = {"a": 1}; m["b"] ?? m["a"];`, "1"},
+		{"null ?? null ?? 3;", "3"},
+		{"def calls = 0; def f = function() { calls++; return 1; }; 5 ?? f(); calls;", "0"},
+		{"isDefined(null);", "false"},
+		{"isDefined(0);", "true"},
+		{`def m = {"a": 1}; [isDefined(m["a"]), isDefined(m["b"])];`, "[true, false]"},
+		{"match (null) { case null => { 1; } default => { 2; } };", "1"},
+		{"!null;", "true"},
+		{"def user = null; user?.name;", "undefined"},
+		{`def user = {"name": "jipl"}; user["address"]?.city ?? "unknown";`, "unknown"},
+		{"def s = \"abc\"; s?.length;", "3"},
+		{"class P { def x = 1; } def p = P(); p?.x;", "1"},
+		{"def u = null; u?.name.first ?? \"none\";", "none"},
+		{"def u = null; u?.upper() ?? \"none\";", "none"},
+		{"def u = null; u?.items[0].name(1, 2) ?? \"none\";", "none"},
+		{"def calls = 0; def f = function() { calls++; return 0; }; def u = null; u?.at(f())[f()]; calls;", "0"},
+		{"def s = \"abc\"; s?.upper().lower();", "abc"},
+		{"class P { def next = null; } def p = P(); p.next?.next.next ?? \"end\";", "end"},
+	}
+
+	errorPositionsEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{"def a = 1;\ndef b = a + \"x\";", "2:11: type mismatch: INTEGER + STRING"},
+		{"def f = function(x) {\n  return x.missing;\n};\nf(1);", "2:11: INTEGER has no member 'missing'"},
+		{"def a = 1;\n\n   b;", "3:4: identifier not found: b"},
+	}
+
+	errorPropagationEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{"-missing;", "identifier not found: missing"},
+		{"!missing;", "identifier not found: missing"},
+		{"missing + 1;", "identifier not found: missing"},
+		{"1 + missing;", "identifier not found: missing"},
+		{"(1 + missing) * 2;", "identifier not found: missing"},
+		{"if (missing) { 1; }", "identifier not found: missing"},
+		{"if (false) { 1; } else if (missing) { 2; }", "identifier not found: missing"},
+		{"(1 / 0)++;", "division by zero"},
+		{"def f = function() { return 1 % 0; }; f() + 1;", "modulo by zero"},
+		{"def a = 5; a /= 0;", "division by zero"},
+		{"def a = [4]; a[0] %= 0;", "modulo by zero"},
+		{"-out(1);", "operand is not a number"},
+		{"out(1) + 1;", "type mismatch: UNDEFINED + INTEGER"},
+		{"def s = \"x\"; s?.nope.first;", "STRING has no member 'nope'"},
+	}
+
+	callStackEvalData = []struct {
+		input    string
+		expected string
+	}{
+		{"def f = function() { return 1 + \"a\"; };\nf();", "f@2:1"},
+		{"function inner(x) { return x / 0; }\nfunction outer(x) {\n  return inner(x);\n}\nouter(1);",
+			"outer@5:1 inner@3:10"},
+		{"def apply = (f, x) => f(x);\napply((x) => x.missing, 1);", "apply@2:1 f@1:23"},
+		{"class P { def x = 1; function add(v) { return x + v; } }\ndef p = P();\np.add(\"a\");", "add@3:1"},
+		{"class P { constructor(v) { missing; } }\nP(1);", "P@2:1"},
+		{"length(1, 2);", ""},
+		{"function f(a) { return a; }\nf(1, 2);", ""},
+		{"function f(a, b) { return a; }\nf(b = 1);", ""},
+		{"function g(x) { return f(); }\nfunction f(a) { return a; }\ng(1);", "g@3:1"},
+		{"class P { constructor(v) { } }\nP();", ""},
+		{"function f(a = missing) { return a; }\nf();", "f@2:1"},
+	}
 )
@@ -0,0 +1,63 @@
+package runtime
+
+import (
+	"fmt"
+
+	ast "github.com/houcine7/JIPL/internal/AST"
+	"github.com/houcine7/JIPL/internal/debug"
+	"github.com/houcine7/JIPL/internal/types"
+)
+
+func evalMapLiteral(node *ast.MapLiteral, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
+	m := types.NewMap()
+
+	for _, pair := range node.Pairs {
+		keyObj, err := Eval(pair.Key, ctx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		key, err := hashKey(keyObj)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		val, err := Eval(pair.Value, ctx)
+		if err != debug.NOERROR {
+			return nil, err
+		}
+		m.Set(key, val)
+	}
+
+	return m, debug.NOERROR
+}
+
+// a missing key evaluates to undefined
+func evalMapIndexExpression(m *types.Map, index types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	key, err := hashKey(index)
+	if err != debug.NOERROR {
+		return nil, err
+	}
+	if val, ok := m.Get(key); ok {
+		return val, debug.NOERROR
+	}
+	return types.UNDEFIEND, debug.NOERROR
+}
+
+func evalMapInfixExpression(operator string, left, right types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	switch operator {
+	case "==":
+		return types.BoolToObJIPL(objectsEqual(left, right)), debug.NOERROR
+	case "!=":
+		return types.BoolToObJIPL(!objectsEqual(left, right)), debug.NOERROR
+	default:
+		return nil, debug.NewError("unknown operator")
+	}
+}
+
+// only strings, integers and booleans can be used as map keys
+func hashKey(obj types.ObjectJIPL) (types.Hashable, *debug.Error) {
+	key, ok := obj.(types.Hashable)
+	if !ok {
+		return nil, debug.NewError(fmt.Sprintf("unusable as map key: %s", obj.GetType()))
+	}
+	return key, debug.NOERROR
+}
@@ -0,0 +1,172 @@
+package runtime
+
+import (
+	"fmt"
+
+	ast "github.com/houcine7/JIPL/internal/AST"
+	"github.com/houcine7/JIPL/internal/debug"
+	"github.com/houcine7/JIPL/internal/types"
+)
+
+// the type names usable as patterns, case INTEGER => ...
+var patternTypes = map[string]types.TypeObj{
+	"INTEGER":   types.T_INTEGER,
+	"FLOAT":     types.T_FLOAT,
+	"BOOLEAN":   types.T_BOOLEAN,
+	"UNDEFINED": types.T_UNDEFINED,
+	"STRING":    types.T_STRING,
+	"FUNCTION":  types.T_FUNCTION,
+	"BUILTIN":   types.T_BUILTIN,
+	"ARRAY":     types.T_ARRAY,
+	"MAP":       types.T_MAP,
+	"CLASS":     types.T_CLASS,
+	"INSTANCE":  types.T_INSTANCE,
+}
+
+// evaluates the body of the first arm with a matching pattern, the names bound
+// by the pattern are only visible in the body of the arm
+func evalMatchExpression(node *ast.MatchExpression, ctx *types.Context) (types.ObjectJIPL, *debug.Error) {
+	value, err := Eval(node.Value, ctx)
+	if err != debug.NOERROR {
+		return nil, err
+	}
+
+	for _, arm := range node.Arms {
+		for _, pattern := range arm.Patterns {
+			armCtx := types.NewContextWithOuter(ctx)
+			matched, err := matchPattern(pattern, value, armCtx)
+			if err != debug.NOERROR {
+				return nil, err
+			}
+			if matched {
+				// the body shares the scope of the bindings
+				return evalABlockStatements(arm.Body.Statements, armCtx)
+			}
+		}
+	}
+
+	if node.Default != nil {
+		return Eval(node.Default, ctx)
+	}
+	return types.UNDEFIEND, debug.NOERROR
+}
+
+// reports whether the value matches the pattern, the names of the pattern are bound in ctx
+func matchPattern(pattern ast.Expression, value types.ObjectJIPL, ctx *types.Context) (bool, *debug.Error) {
+	mismatch, err := patternMismatch(pattern, value, ctx)
+	return mismatch == "", err
+}
+
+// binds the names of the pattern to the destructured value, a value that doesn't
+// have the shape of the pattern is an error
+func bindPattern(pattern ast.Expression, value types.ObjectJIPL, ctx *types.Context) *debug.Error {
+	mismatch, err := patternMismatch(pattern, value, ctx)
+	if err != debug.NOERROR {
+		return err
+	}
+	if mismatch != "" {
+		return debug.NewError(mismatch)
+	}
+	return debug.NOERROR
+}
+
+// appends the names of the pattern to names in the order they appear, once each
+func patternNames(pattern ast.Expression, names []string) []string {
+	switch pat := pattern.(type) {
+	case *ast.Identifier:
+		if pat.Value != "_" && indexOf(names, pat.Value) == -1 {
+			names = append(names, pat.Value)
+		}
+	case *ast.SpreadExpression:
+		names = patternNames(pat.Value, names)
+	case *ast.ArrayLiteral:
+		for _, element := range pat.Values {
+			names = patternNames(element, names)
+		}
+	}
+	return names
+}
+
+// returns why the value doesn't match the pattern or an empty string if it matches:
+//   - _ matches anything
+//   - a type name matches the values of that type and a class name the instances of the class
+//   - any other name matches anything and is bound to the value
+//   - an array pattern matches the arrays of the same length with matching elements,
+//     a trailing ...rest collects the remaining elements
+//   - a literal matches the equal values
+func patternMismatch(pattern ast.Expression, value types.ObjectJIPL, ctx *types.Context) (string, *debug.Error) {
+	if value == nil {
+		value = types.UNDEFIEND
+	}
+
+	switch pat := pattern.(type) {
+	case *ast.Identifier:
+		if pat.Value == "_" {
+			return "", debug.NOERROR
+		}
+		if typ, ok := patternTypes[pat.Value]; ok {
+			if value.GetType() != typ {
+				return fmt.Sprintf("expected a value of type %s instead got %s", typ, value.GetType()), debug.NOERROR
+			}
+			return "", debug.NOERROR
+		}
+		if obj, ok := ctx.Get(pat.Value); ok {
+			if class, isClass := obj.(*types.Class); isClass {
+				if inst, isInstance := value.(*types.Instance); !isInstance || inst.Class != class {
+					return fmt.Sprintf("expected an instance of %s instead got %s", class.Name, value.ToString()), debug.NOERROR
+				}
+				return "", debug.NOERROR
+			}
+		}
+		ctx.Set(pat.Value, value)
+		return "", debug.NOERROR
+	case *ast.ArrayLiteral:
+		return arrayPatternMismatch(pat, value, ctx)
+	default:
+		expected, err := Eval(pattern, ctx)
+		if err != debug.NOERROR {
+			return "", err
+		}
+		if !objectsEqual(value, expected) {
+			return fmt.Sprintf("the value %s doesn't match the pattern %s", value.ToString(), pattern.ToString()), debug.NOERROR
+		}
+		return "", debug.NOERROR
+	}
+}
+
+func arrayPatternMismatch(pattern *ast.ArrayLiteral, value types.ObjectJIPL, ctx *types.Context) (string, *debug.Error) {
+	arr, ok := value.(*types.Array)
+	if !ok {
+		return fmt.Sprintf("cannot destructure a value of type %s, expected an ARRAY", value.GetType()), debug.NOERROR
+	}
+
+	elements := pattern.Values
+	var rest *ast.Identifier
+	if len(elements) > 0 {
+		if spread, ok := elements[len(elements)-1].(*ast.SpreadExpression); ok {
+			rest = spread.Value.(*ast.Identifier)
+			elements = elements[:len(elements)-1]
+		}
+	}
+
+	if rest == nil && len(arr.Elements) != len(elements) {
+		return fmt.Sprintf("cannot destructure an array of length %d into %d elements", len(arr.Elements), len(elements)), debug.NOERROR
+	}
+	if len(arr.Elements) < len(elements) {
+		return fmt.Sprintf("cannot destructure an array of length %d into at least %d elements", len(arr.Elements), len(elements)), debug.NOERROR
+	}
+
+	for i, element := range elements {
+		mismatch, err := patternMismatch(element, arr.Elements[i], ctx)
+		if err != debug.NOERROR || mismatch != "" {
+			return mismatch, err
+		}
+	}
+
+	if rest != nil {
+		remaining := make([]types.ObjectJIPL, len(arr.Elements)-len(elements))
+		copy(remaining, arr.Elements[len(elements):])
+		ctx.Set(rest.Value, &types.Array{Elements: remaining})
+	}
+	return "", debug.NOERROR
+}
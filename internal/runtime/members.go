@@ -0,0 +1,170 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/houcine7/JIPL/internal/debug"
+	"github.com/houcine7/JIPL/internal/types"
+)
+
+// a member of a built-in type: properties are computed on access
+// while methods are bound to their receiver and called later
+type member struct {
+	property bool
+	fn       func(receiver types.ObjectJIPL, args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error)
+}
+
+// the members table of the built-in types
+var members = map[types.TypeObj]map[string]member{
+	types.T_STRING: {
+		"length": {property: true, fn: func(receiver types.ObjectJIPL, args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+			return &types.Integer{Val: len(receiver.(*types.String).Val)}, debug.NOERROR
+		}},
+		"upper": {fn: func(receiver types.ObjectJIPL, args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+			if err := checkArgsCount("upper", args, 0); err != debug.NOERROR {
+				return nil, err
+			}
+			return &types.String{Val: strings.ToUpper(receiver.(*types.String).Val)}, debug.NOERROR
+		}},
+		"lower": {fn: func(receiver types.ObjectJIPL, args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+			if err := checkArgsCount("lower", args, 0); err != debug.NOERROR {
+				return nil, err
+			}
+			return &types.String{Val: strings.ToLower(receiver.(*types.String).Val)}, debug.NOERROR
+		}},
+		"trim": {fn: func(receiver types.ObjectJIPL, args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+			if err := checkArgsCount("trim", args, 0); err != debug.NOERROR {
+				return nil, err
+			}
+			return &types.String{Val: strings.TrimSpace(receiver.(*types.String).Val)}, debug.NOERROR
+		}},
+		"contains": {fn: func(receiver types.ObjectJIPL, args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+			sub, err := stringArg("contains", args)
+			if err != debug.NOERROR {
+				return nil, err
+			}
+			return types.BoolToObJIPL(strings.Contains(receiver.(*types.String).Val, sub)), debug.NOERROR
+		}},
+		"startsWith": {fn: func(receiver types.ObjectJIPL, args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+			prefix, err := stringArg("startsWith", args)
+			if err != debug.NOERROR {
+				return nil, err
+			}
+			return types.BoolToObJIPL(strings.HasPrefix(receiver.(*types.String).Val, prefix)), debug.NOERROR
+		}},
+		"endsWith": {fn: func(receiver types.ObjectJIPL, args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+			suffix, err := stringArg("endsWith", args)
+			if err != debug.NOERROR {
+				return nil, err
+			}
+			return types.BoolToObJIPL(strings.HasSuffix(receiver.(*types.String).Val, suffix)), debug.NOERROR
+		}},
+		"split": {fn: func(receiver types.ObjectJIPL, args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+			sep, err := stringArg("split", args)
+			if err != debug.NOERROR {
+				return nil, err
+			}
+			parts := strings.Split(receiver.(*types.String).Val, sep)
+			elements := make([]types.ObjectJIPL, len(parts))
+			for i, part := range parts {
+				elements[i] = &types.String{Val: part}
+			}
+			return &types.Array{Elements: elements}, debug.NOERROR
+		}},
+	},
+	types.T_ARRAY: {
+		"length": {property: true, fn: func(receiver types.ObjectJIPL, args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+			return &types.Integer{Val: len(receiver.(*types.Array).Elements)}, debug.NOERROR
+		}},
+		"push": {fn: func(receiver types.ObjectJIPL, args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+			arr := receiver.(*types.Array)
+			arr.Elements = append(arr.Elements, args...)
+			return &types.Integer{Val: len(arr.Elements)}, debug.NOERROR
+		}},
+		"join": {fn: func(receiver types.ObjectJIPL, args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+			sep, err := stringArg("join", args)
+			if err != debug.NOERROR {
+				return nil, err
+			}
+			elements := receiver.(*types.Array).Elements
+			parts := make([]string, len(elements))
+			for i, el := range elements {
+				parts[i] = el.ToString()
+			}
+			return &types.String{Val: strings.Join(parts, sep)}, debug.NOERROR
+		}},
+	},
+	types.T_FUNCTION: {
+		"name": {property: true, fn: func(receiver types.ObjectJIPL, args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+			return &types.String{Val: receiver.(*types.Function).Name}, debug.NOERROR
+		}},
+		"arity": {property: true, fn: func(receiver types.ObjectJIPL, args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+			return &types.Integer{Val: len(receiver.(*types.Function).Params)}, debug.NOERROR
+		}},
+	},
+	types.T_CLASS: {
+		"name": {property: true, fn: func(receiver types.ObjectJIPL, args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+			return &types.String{Val: receiver.(*types.Class).Name}, debug.NOERROR
+		}},
+	},
+}
+
+func evalMemberExpression(object types.ObjectJIPL, name string) (types.ObjectJIPL, *debug.Error) {
+	if inst, ok := object.(*types.Instance); ok {
+		return evalInstanceMember(inst, name)
+	}
+
+	m, ok := members[object.GetType()][name]
+	if !ok {
+		return nil, debug.NewError(fmt.Sprintf("%s has no member '%s'", object.GetType(), name))
+	}
+	if m.property {
+		return m.fn(object)
+	}
+	// bind the method to its receiver
+	return &types.BuiltIn{Fn: func(args ...types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+		return m.fn(object, args...)
+	}}, debug.NOERROR
+}
+
+func evalInstanceMember(inst *types.Instance, name string) (types.ObjectJIPL, *debug.Error) {
+	if val, ok := inst.Fields.Store[name]; ok {
+		return val, debug.NOERROR
+	}
+	for _, method := range inst.Class.Methods {
+		if method.Name.Value == name {
+			return inst.Self.Store[name], debug.NOERROR
+		}
+	}
+	return nil, debug.NewError(fmt.Sprintf("instance of %s has no member '%s'", inst.Class.Name, name))
+}
+
+func assignMember(object types.ObjectJIPL, name string, val types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	inst, ok := object.(*types.Instance)
+	if !ok {
+		return nil, debug.NewError(fmt.Sprintf("cannot assign member '%s' of %s", name, object.GetType()))
+	}
+	if _, ok := inst.Fields.Store[name]; !ok {
+		return nil, debug.NewError(fmt.Sprintf("instance of %s has no field '%s'", inst.Class.Name, name))
+	}
+	return inst.Fields.Set(name, val), debug.NOERROR
+}
+
+func checkArgsCount(name string, args []types.ObjectJIPL, expected int) *debug.Error {
+	if len(args) != expected {
+		return debug.NewError(fmt.Sprintf("%s expects %d arguments instead got %d", name, expected, len(args)))
+	}
+	return debug.NOERROR
+}
+
+func stringArg(name string, args []types.ObjectJIPL) (string, *debug.Error) {
+	if err := checkArgsCount(name, args, 1); err != debug.NOERROR {
+		return "", err
+	}
+	str, ok := args[0].(*types.String)
+	if !ok {
+		return "", debug.NewError(fmt.Sprintf("%s expects a STRING argument instead got %s", name, args[0].GetType()))
+	}
+	return str.Val, debug.NOERROR
+}
@@ -0,0 +1,171 @@
+package runtime
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/houcine7/JIPL/internal/debug"
+	"github.com/houcine7/JIPL/internal/types"
+)
+
+func isNumeric(obj types.ObjectJIPL) bool {
+	return obj.GetType() == types.T_INTEGER || obj.GetType() == types.T_FLOAT
+}
+
+// converts an integer or a float to a go float64
+func toFloat(obj types.ObjectJIPL) float64 {
+	switch num := obj.(type) {
+	case *types.Integer:
+		return float64(num.Val)
+	case *types.Float:
+		return num.Val
+	case *types.BigInteger:
+		val, _ := new(big.Float).SetInt(num.Val).Float64()
+		return val
+	default:
+		return math.NaN()
+	}
+}
+
+// converts an integer (small or big) to a big.Int
+func toBigInt(obj types.ObjectJIPL) *big.Int {
+	switch num := obj.(type) {
+	case *types.Integer:
+		return big.NewInt(int64(num.Val))
+	case *types.BigInteger:
+		return num.Val
+	default:
+		return nil
+	}
+}
+
+// converts an integer to a go int for the builtins and the loops that need one,
+// a big integer is out of range since the integers that fit are never big
+func toInt(obj types.ObjectJIPL) (int, *debug.Error) {
+	switch num := obj.(type) {
+	case *types.Integer:
+		return num.Val, debug.NOERROR
+	case *types.BigInteger:
+		if num.Val.IsInt64() && num.Val.Int64() >= math.MinInt && num.Val.Int64() <= math.MaxInt {
+			return int(num.Val.Int64()), debug.NOERROR
+		}
+		return 0, debug.NewError(fmt.Sprintf("integer out of range: %s", num.Val.String()))
+	default:
+		return 0, debug.NewError(fmt.Sprintf("expected an INTEGER instead got %s", obj.GetType()))
+	}
+}
+
+// converts a float to an integer truncating toward zero, the floats
+// outside the range of an int become big integers
+func floatToInt(val float64) (types.ObjectJIPL, *debug.Error) {
+	if math.IsNaN(val) || math.IsInf(val, 0) {
+		return nil, debug.NewError(fmt.Sprintf("cannot convert %v to an integer", val))
+	}
+	// the bounds are powers of two so they are exact floats
+	if val >= math.MinInt && val < -math.MinInt {
+		return &types.Integer{Val: int(val)}, debug.NOERROR
+	}
+	truncated, _ := new(big.Float).SetFloat64(val).Int(nil)
+	return normalizeBigInt(truncated), debug.NOERROR
+}
+
+// integers are kept as ints while they fit and promoted to big integers otherwise
+func normalizeBigInt(val *big.Int) types.ObjectJIPL {
+	if val.IsInt64() && val.Int64() >= math.MinInt && val.Int64() <= math.MaxInt {
+		return &types.Integer{Val: int(val.Int64())}
+	}
+	return &types.BigInteger{Val: val}
+}
+
+// evaluates the integer operations when one of the operands is a big integer
+// or when the int operation overflows
+func evalBigIntInfixExpression(operator string, left, right types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	leftVal, rightVal := toBigInt(left), toBigInt(right)
+	switch operator {
+	case "+":
+		return normalizeBigInt(new(big.Int).Add(leftVal, rightVal)), debug.NOERROR
+	case "-":
+		return normalizeBigInt(new(big.Int).Sub(leftVal, rightVal)), debug.NOERROR
+	case "*":
+		return normalizeBigInt(new(big.Int).Mul(leftVal, rightVal)), debug.NOERROR
+	case "/":
+		if rightVal.Sign() == 0 {
+			return nil, debug.NewError("division by zero")
+		}
+		return normalizeBigInt(new(big.Int).Quo(leftVal, rightVal)), debug.NOERROR
+	case "%":
+		if rightVal.Sign() == 0 {
+			return nil, debug.NewError("modulo by zero")
+		}
+		return normalizeBigInt(new(big.Int).Rem(leftVal, rightVal)), debug.NOERROR
+	case "==":
+		return types.BoolToObJIPL(leftVal.Cmp(rightVal) == 0), debug.NOERROR
+	case "!=":
+		return types.BoolToObJIPL(leftVal.Cmp(rightVal) != 0), debug.NOERROR
+	case "<":
+		return types.BoolToObJIPL(leftVal.Cmp(rightVal) < 0), debug.NOERROR
+	case "<=":
+		return types.BoolToObJIPL(leftVal.Cmp(rightVal) <= 0), debug.NOERROR
+	case ">":
+		return types.BoolToObJIPL(leftVal.Cmp(rightVal) > 0), debug.NOERROR
+	case ">=":
+		return types.BoolToObJIPL(leftVal.Cmp(rightVal) >= 0), debug.NOERROR
+	default:
+		return nil, debug.NewError("unknown operator")
+	}
+}
+
+// reports whether the int operation overflows and has to be done with big integers
+func intOverflows(operator string, left, right int) bool {
+	switch operator {
+	case "+":
+		res := left + right
+		return (left > 0 && right > 0 && res < 0) || (left < 0 && right < 0 && res >= 0)
+	case "-":
+		res := left - right
+		return (left >= 0 && right < 0 && res < 0) || (left < 0 && right > 0 && res >= 0)
+	case "*":
+		if left == 0 || right == 0 {
+			return false
+		}
+		res := left * right
+		return res/right != left || (left == -1 && right == math.MinInt) || (right == -1 && left == math.MinInt)
+	case "/":
+		return left == math.MinInt && right == -1
+	default:
+		return false
+	}
+}
+
+// evaluates the operations where at least one of the operands is a float,
+// the integer operand is converted to a float
+func evalFloatInfixExpression(operator string, left, right types.ObjectJIPL) (types.ObjectJIPL, *debug.Error) {
+	leftVal, rightVal := toFloat(left), toFloat(right)
+	switch operator {
+	case "+":
+		return &types.Float{Val: leftVal + rightVal}, debug.NOERROR
+	case "-":
+		return &types.Float{Val: leftVal - rightVal}, debug.NOERROR
+	case "*":
+		return &types.Float{Val: leftVal * rightVal}, debug.NOERROR
+	case "/":
+		return &types.Float{Val: leftVal / rightVal}, debug.NOERROR
+	case "%":
+		return &types.Float{Val: math.Mod(leftVal, rightVal)}, debug.NOERROR
+	case "==":
+		return types.BoolToObJIPL(leftVal == rightVal), debug.NOERROR
+	case "!=":
+		return types.BoolToObJIPL(leftVal != rightVal), debug.NOERROR
+	case "<":
+		return types.BoolToObJIPL(leftVal < rightVal), debug.NOERROR
+	case "<=":
+		return types.BoolToObJIPL(leftVal <= rightVal), debug.NOERROR
+	case ">":
+		return types.BoolToObJIPL(leftVal > rightVal), debug.NOERROR
+	case ">=":
+		return types.BoolToObJIPL(leftVal >= rightVal), debug.NOERROR
+	default:
+		return nil, debug.NewError("unknown operator")
+	}
+}
@@ -1,10 +1,40 @@
 package token
 
+import "fmt"
+
 type TokenType int
 
 type Token struct {
 	Type  TokenType
 	Value string
+	Pos   Position // where the token starts in the source
+}
+
+// a location in the source, lines and columns start at 1
+// the zero Position is unknown
+type Position struct {
+	File   string // the name of the source file, empty for the repl input
+	Line   int
+	Column int // counted in characters
+	Offset int // the byte offset from the start of the source
+}
+
+func (pos Position) IsValid() bool {
+	return pos.Line > 0
+}
+
+func (pos Position) String() string {
+	loc := pos.File
+	if pos.IsValid() {
+		if loc != "" {
+			loc += ":"
+		}
+		loc += fmt.Sprintf("%d:%d", pos.Line, pos.Column)
+	}
+	if loc == "" {
+		return "-"
+	}
+	return loc
 }
 
 /*
@@ -23,9 +53,17 @@ var keywords = map[string]TokenType{
 	"return":      RETURN,
 	"true":        TRUE,
 	"false":       FALSE,
+	"null":        NULL,
 	"for":         FOR,
+	"in":          IN,
+	"while":       WHILE,
+	"do":          DO,
+	"match":       MATCH,
+	"case":        CASE,
+	"default":     DEFAULT,
 	"function":    FUNCTION,
 	"def":         DEF,
+	"const":       CONST,
 	"if":          IF,
 	"else":        ELSE,
 	"class":       CLASS,
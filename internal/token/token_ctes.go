@@ -11,6 +11,7 @@ const (
 
 	// literals [2,]
 	INT    // int values
+	FLOAT  // float values
 	STRING // string values
 
 	//OPERATORS  values [40,80]
@@ -27,6 +28,13 @@ const (
 	MODULO    // %
 	AND       // &&
 	OR        // ||
+	NULLISH   // ??
+
+	PLUS_ASSIGN   // +=
+	MINUS_ASSIGN  // -=
+	STAR_ASSIGN   // *=
+	SLASH_ASSIGN  // /=
+	MODULO_ASSIGN // %=
 
 	/*Comparators operators*/
 	LT       // <
@@ -35,8 +43,13 @@ const (
 	GT_OR_EQ // >=
 
 	//DELIMITERS [20,39]
-	COMMA   // ,
-	S_COLON // ;
+	COMMA    // ,
+	S_COLON  // ;
+	DOT      // .
+	OPT_DOT  // ?. optional chaining
+	COLON    // :
+	ARROW    // =>
+	ELLIPSIS // ...
 
 	LP // (
 	RP // )
@@ -49,6 +62,7 @@ const (
 	// KEYWORDS
 	FUNCTION // function keyword
 	DEF      // an identifier definition
+	CONST    // a read-only identifier definition
 	IF       // if token
 	ELSE     // else token
 	RETURN   // return statement toke
@@ -56,7 +70,15 @@ const (
 	CONTINUE // continue token
 	TRUE
 	FALSE
+	NULL // the null literal, the undefined value
 	FOR
+	IN    // the in of for (def x in collection)
+	WHILE // while loops
+	DO    // do while loops
+
+	MATCH   // match expressions
+	CASE    // the arms of a match expression
+	DEFAULT // the default arm of a match expression
 
 	CLASS       // the class key word to create a class
 	CONSTRUCTOR // constructor keyword
@@ -0,0 +1,84 @@
+package token
+
+import "fmt"
+
+// the human readable names of the token types used in the error messages
+var names = map[TokenType]string{
+	ILLEGAL:    "illegal character",
+	FILE_ENDED: "end of input",
+
+	IDENTIFIER: "identifier",
+
+	INT:    "integer",
+	FLOAT:  "float",
+	STRING: "string",
+
+	ASSIGN:    "=",
+	PLUS:      "+",
+	MINUS:     "-",
+	STAR:      "*",
+	SLASH:     "/",
+	EX_MARK:   "!",
+	EQUAL:     "==",
+	NOT_EQUAL: "!=",
+	INCREMENT: "++",
+	DECREMENT: "--",
+	MODULO:    "%",
+	AND:       "&&",
+	OR:        "||",
+	NULLISH:   "??",
+
+	PLUS_ASSIGN:   "+=",
+	MINUS_ASSIGN:  "-=",
+	STAR_ASSIGN:   "*=",
+	SLASH_ASSIGN:  "/=",
+	MODULO_ASSIGN: "%=",
+
+	LT:       "<",
+	GT:       ">",
+	LT_OR_EQ: "<=",
+	GT_OR_EQ: ">=",
+
+	COMMA:    ",",
+	S_COLON:  ";",
+	DOT:      ".",
+	OPT_DOT:  "?.",
+	COLON:    ":",
+	ARROW:    "=>",
+	ELLIPSIS: "...",
+
+	LP:  "(",
+	RP:  ")",
+	LCB: "{",
+	RCB: "}",
+	RB:  "]",
+	LB:  "[",
+
+	FUNCTION:    "function",
+	DEF:         "def",
+	CONST:       "const",
+	IF:          "if",
+	ELSE:        "else",
+	RETURN:      "return",
+	BREAK:       "break",
+	CONTINUE:    "continue",
+	TRUE:        "true",
+	FALSE:       "false",
+	NULL:        "null",
+	FOR:         "for",
+	IN:          "in",
+	WHILE:       "while",
+	DO:          "do",
+	MATCH:       "match",
+	CASE:        "case",
+	DEFAULT:     "default",
+	CLASS:       "class",
+	CONSTRUCTOR: "constructor",
+}
+
+func (tokenType TokenType) String() string {
+	if name, ok := names[tokenType]; ok {
+		return name
+	}
+	return fmt.Sprintf("token(%d)", int(tokenType))
+}
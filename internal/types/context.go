@@ -1,20 +1,38 @@
 package types
 
+import "github.com/houcine7/JIPL/internal/debug"
+
 type Context struct {
-	Store map[string]ObjectJIPL
-	Outer *Context // the outer scope
+	Store  map[string]ObjectJIPL
+	Consts map[string]bool // the read-only bindings of the store
+	Outer  *Context        // the outer scope
+
+	AllowShadowing bool // allows the definitions that shadow the builtins
+	InFunction     bool // the scope is inside the body of a function, return statements are allowed
+
+	Calls *CallStack // the calls being evaluated, shared by all the contexts of a program
+}
+
+// the calls of the functions and classes of a program, the innermost call is the last one
+type CallStack struct {
+	Frames []debug.Frame
 }
 
 func NewContext() *Context {
 	return &Context{
-		Store: make(map[string]ObjectJIPL),
-		Outer: nil,
+		Store:  make(map[string]ObjectJIPL),
+		Consts: make(map[string]bool),
+		Outer:  nil,
+		Calls:  &CallStack{},
 	}
 }
 
 func NewContextWithOuter(outer *Context) *Context {
 	ctx := NewContext()
 	ctx.Outer = outer
+	ctx.Calls = outer.Calls
+	ctx.AllowShadowing = outer.AllowShadowing
+	ctx.InFunction = outer.InFunction
 	return ctx
 }
 
@@ -32,3 +50,35 @@ func (ctx *Context) Set(key string, val ObjectJIPL) ObjectJIPL {
 	ctx.Store[key] = val
 	return val
 }
+
+// SetConst creates a read-only binding in the current context
+func (ctx *Context) SetConst(key string, val ObjectJIPL) ObjectJIPL {
+	ctx.Store[key] = val
+	ctx.Consts[key] = true
+	return val
+}
+
+// IsConst reports whether the key is bound to a read-only binding
+// in the context (current or outer) where it's declared
+func (ctx *Context) IsConst(key string) bool {
+	if _, ok := ctx.Store[key]; ok {
+		return ctx.Consts[key]
+	}
+	if ctx.Outer != nil {
+		return ctx.Outer.IsConst(key)
+	}
+	return false
+}
+
+// Assign updates the binding of the key in the context (current or outer)
+// where it was declared, it reports false if the key is not declared
+func (ctx *Context) Assign(key string, val ObjectJIPL) (ObjectJIPL, bool) {
+	if _, ok := ctx.Store[key]; ok {
+		ctx.Store[key] = val
+		return val, true
+	}
+	if ctx.Outer != nil {
+		return ctx.Outer.Assign(key, val)
+	}
+	return nil, false
+}
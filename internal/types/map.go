@@ -0,0 +1,116 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// the key used to store a value in a map: values of different types
+// never share a key (1 and "1" are different keys)
+type HashKey struct {
+	Type  TypeObj
+	Value string
+}
+
+// implemented by the types that can be used as map keys
+type Hashable interface {
+	ObjectJIPL
+	HashKey() HashKey
+}
+
+type MapPair struct {
+	Key   ObjectJIPL
+	Value ObjectJIPL
+}
+
+type Map struct {
+	Pairs map[HashKey]MapPair
+	Keys  []HashKey // keys in insertion order
+}
+
+func NewMap() *Map {
+	return &Map{Pairs: make(map[HashKey]MapPair)}
+}
+
+func (m *Map) Get(key Hashable) (ObjectJIPL, bool) {
+	pair, ok := m.Pairs[key.HashKey()]
+	return pair.Value, ok
+}
+
+func (m *Map) Set(key Hashable, val ObjectJIPL) ObjectJIPL {
+	hash := key.HashKey()
+	if _, ok := m.Pairs[hash]; !ok {
+		m.Keys = append(m.Keys, hash)
+	}
+	m.Pairs[hash] = MapPair{Key: key, Value: val}
+	return val
+}
+
+func (m *Map) Delete(key Hashable) bool {
+	hash := key.HashKey()
+	if _, ok := m.Pairs[hash]; !ok {
+		return false
+	}
+	delete(m.Pairs, hash)
+	for i, k := range m.Keys {
+		if k == hash {
+			m.Keys = append(m.Keys[:i], m.Keys[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// the pairs of the map in insertion order
+func (m *Map) Entries() []MapPair {
+	entries := make([]MapPair, len(m.Keys))
+	for i, k := range m.Keys {
+		entries[i] = m.Pairs[k]
+	}
+	return entries
+}
+
+func (m *Map) GetType() TypeObj {
+	return T_MAP
+}
+
+func (m *Map) ToString() string {
+	return m.toString(visiting{})
+}
+
+func (m *Map) toString(seen visiting) string {
+	if seen[m] {
+		return "{...}"
+	}
+	seen[m] = true
+	defer delete(seen, m)
+
+	var bf bytes.Buffer
+	bf.WriteRune('{')
+	for idx, pair := range m.Entries() {
+		bf.WriteString(pair.Key.ToString())
+		bf.WriteString(": ")
+		bf.WriteString(stringOf(pair.Value, seen))
+		if idx != len(m.Keys)-1 {
+			bf.WriteString(", ")
+		}
+	}
+	bf.WriteRune('}')
+	return bf.String()
+}
+
+func (intObj *Integer) HashKey() HashKey {
+	return HashKey{Type: T_INTEGER, Value: fmt.Sprintf("%d", intObj.Val)}
+}
+
+func (bigObj *BigInteger) HashKey() HashKey {
+	return HashKey{Type: T_INTEGER, Value: bigObj.Val.String()}
+}
+
+func (str *String) HashKey() HashKey {
+	return HashKey{Type: T_STRING, Value: str.Val}
+}
+
+func (boolObj *Boolean) HashKey() HashKey {
+	return HashKey{Type: T_BOOLEAN, Value: fmt.Sprintf("%t", boolObj.Val)}
+}
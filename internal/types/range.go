@@ -0,0 +1,37 @@
+package types
+
+import "fmt"
+
+// the integers from Start to End (excluded) by Step, they are computed
+// when they are used so a range doesn't hold its elements in memory
+type Range struct {
+	Start int
+	End   int
+	Step  int // never zero
+}
+
+// the number of integers of the range
+func (r *Range) Len() int {
+	switch {
+	case r.Step > 0 && r.Start < r.End:
+		// unsigned arithmetic so the distance between the bounds can't overflow
+		return int((uint(r.End)-uint(r.Start)-1)/uint(r.Step) + 1)
+	case r.Step < 0 && r.Start > r.End:
+		return int((uint(r.Start)-uint(r.End)-1)/uint(-r.Step) + 1)
+	default:
+		return 0
+	}
+}
+
+// the integer at the index, the index should be less than the length
+func (r *Range) At(idx int) int {
+	return r.Start + idx*r.Step
+}
+
+func (r *Range) GetType() TypeObj {
+	return T_RANGE
+}
+
+func (r *Range) ToString() string {
+	return fmt.Sprintf("range(%d, %d, %d)", r.Start, r.End, r.Step)
+}
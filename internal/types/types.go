@@ -3,6 +3,9 @@ package types
 import (
 	"bytes"
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 
 	ast "github.com/houcine7/JIPL/internal/AST"
 	"github.com/houcine7/JIPL/internal/debug"
@@ -19,6 +22,16 @@ type Integer struct {
 	Val int
 }
 
+// integers that don't fit in an int, they share the INTEGER type
+// with the Integer object and are only created when an operation overflows
+type BigInteger struct {
+	Val *big.Int
+}
+
+type Float struct {
+	Val float64
+}
+
 type String struct {
 	Val string
 }
@@ -28,19 +41,45 @@ type Boolean struct {
 
 type Undefined struct{}
 
+type Array struct {
+	Elements []ObjectJIPL
+}
+
 type Return struct {
 	Val ObjectJIPL
 }
 
+// loop control flow signals
+type Break struct{}
+type Continue struct{}
+
 type Function struct {
-	Name   string
-	Params []*ast.Identifier
-	Body   *ast.BlockStm
-	Ctx    *Context
+	Name     string
+	Params   []*ast.Identifier   // nil for the destructured params
+	Defaults []ast.Expression    // nil for the required params
+	Patterns []*ast.ArrayLiteral // nil for the params that aren't destructured
+	Rest     *ast.Identifier
+	Body     *ast.BlockStm
+	Ctx      *Context
+}
+
+type Class struct {
+	Name        string
+	Constructor *ast.FunctionExp
+	Fields      []*ast.DefStatement
+	Methods     []*ast.FunctionExp
+	Ctx         *Context // the context where the class was defined
+}
+
+type Instance struct {
+	Class  *Class
+	Fields *Context // instance state, its outer is the class context
+	Self   *Context // holds the 'this' binding and the bound methods
 }
 
 type BuiltIn struct {
-	Fn func(args ...ObjectJIPL) (ObjectJIPL, *debug.Error)
+	Fn     func(args ...ObjectJIPL) (ObjectJIPL, *debug.Error)
+	Params []string // the names of the params, used to match named arguments
 }
 
 // implementing OBjectJIPL interface by supported types
@@ -53,11 +92,26 @@ func (fn *Function) ToString() string {
 	bf.WriteString(fn.Name)
 	bf.WriteString("(")
 	for idx, param := range fn.Params {
-		bf.WriteString(param.Value)
+		if param == nil {
+			bf.WriteString(fn.Patterns[idx].ToString())
+		} else {
+			bf.WriteString(param.Value)
+		}
+		if idx < len(fn.Defaults) && fn.Defaults[idx] != nil {
+			bf.WriteString("=")
+			bf.WriteString(fn.Defaults[idx].ToString())
+		}
 		if idx != len(fn.Params)-1 {
 			bf.WriteString(",")
 		}
 	}
+	if fn.Rest != nil {
+		if len(fn.Params) > 0 {
+			bf.WriteString(",")
+		}
+		bf.WriteString("..." + fn.Rest.Value)
+	}
+	bf.WriteString(")")
 	bf.WriteString(fn.Body.ToString())
 
 	return bf.String()
@@ -70,6 +124,20 @@ func (ret *Return) GetType() TypeObj {
 	return T_RETURN
 }
 
+func (br *Break) ToString() string {
+	return "break"
+}
+func (br *Break) GetType() TypeObj {
+	return T_BREAK
+}
+
+func (cont *Continue) ToString() string {
+	return "continue"
+}
+func (cont *Continue) GetType() TypeObj {
+	return T_CONTINUE
+}
+
 func (und *Undefined) ToString() string {
 	return "undefined"
 }
@@ -94,6 +162,111 @@ func (intObj *Integer) GetType() TypeObj {
 	return T_INTEGER
 }
 
+func (arr *Array) GetType() TypeObj {
+	return T_ARRAY
+}
+
+func (arr *Array) ToString() string {
+	return arr.toString(visiting{})
+}
+
+func (arr *Array) toString(seen visiting) string {
+	if seen[arr] {
+		return "[...]"
+	}
+	seen[arr] = true
+	defer delete(seen, arr)
+
+	var bf bytes.Buffer
+	bf.WriteRune('[')
+	for idx, el := range arr.Elements {
+		bf.WriteString(stringOf(el, seen))
+		if idx != len(arr.Elements)-1 {
+			bf.WriteString(", ")
+		}
+	}
+	bf.WriteRune(']')
+	return bf.String()
+}
+
+func (class *Class) GetType() TypeObj {
+	return T_CLASS
+}
+
+func (class *Class) ToString() string {
+	return "class " + class.Name
+}
+
+func (inst *Instance) GetType() TypeObj {
+	return T_INSTANCE
+}
+
+func (inst *Instance) ToString() string {
+	return inst.toString(visiting{})
+}
+
+func (inst *Instance) toString(seen visiting) string {
+	if seen[inst] {
+		return inst.Class.Name + "{...}"
+	}
+	seen[inst] = true
+	defer delete(seen, inst)
+
+	var bf bytes.Buffer
+	bf.WriteString(inst.Class.Name)
+	bf.WriteRune('{')
+	for idx, field := range inst.Class.Fields {
+		bf.WriteString(field.Name.Value)
+		bf.WriteString(": ")
+		if val, ok := inst.Fields.Store[field.Name.Value]; ok {
+			bf.WriteString(stringOf(val, seen))
+		}
+		if idx != len(inst.Class.Fields)-1 {
+			bf.WriteString(", ")
+		}
+	}
+	bf.WriteRune('}')
+	return bf.String()
+}
+
+// the containers being converted to a string, a container met again
+// inside itself is a cycle and is shown as [...] or {...}
+type visiting map[ObjectJIPL]bool
+
+func stringOf(obj ObjectJIPL, seen visiting) string {
+	switch obj := obj.(type) {
+	case *Array:
+		return obj.toString(seen)
+	case *Map:
+		return obj.toString(seen)
+	case *Instance:
+		return obj.toString(seen)
+	default:
+		return obj.ToString()
+	}
+}
+
+func (bigObj *BigInteger) ToString() string {
+	return bigObj.Val.String()
+}
+
+func (bigObj *BigInteger) GetType() TypeObj {
+	return T_INTEGER
+}
+
+func (floatObj *Float) ToString() string {
+	str := strconv.FormatFloat(floatObj.Val, 'g', -1, 64)
+	// keep the fraction part so floats are not confused with integers
+	if !strings.ContainsAny(str, ".eEnN") {
+		str += ".0"
+	}
+	return str
+}
+
+func (floatObj *Float) GetType() TypeObj {
+	return T_FLOAT
+}
+
 func BoolToObJIPL(bl bool) ObjectJIPL {
 	if bl {
 		return TRUE
@@ -119,16 +292,26 @@ func (bi *BuiltIn) ToString() string {
 // cte of types
 const (
 	T_INTEGER   = "INTEGER"
+	T_FLOAT     = "FLOAT"
 	T_BOOLEAN   = "BOOLEAN"
 	T_UNDEFINED = "UNDEFINED"
 	T_RETURN    = "RETURN"
+	T_BREAK     = "BREAK"
+	T_CONTINUE  = "CONTINUE"
 	T_FUNCTION  = "FUNCTION"
 	T_STRING    = "STRING"
 	T_BUILTIN   = "BUILTIN"
+	T_ARRAY     = "ARRAY"
+	T_CLASS     = "CLASS"
+	T_INSTANCE  = "INSTANCE"
+	T_MAP       = "MAP"
+	T_RANGE     = "RANGE"
 )
 
 var (
 	TRUE      = &Boolean{Val: true}
 	FALSE     = &Boolean{Val: false}
 	UNDEFIEND = &Undefined{}
+	BREAK     = &Break{}
+	CONTINUE  = &Continue{}
 )